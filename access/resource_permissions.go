@@ -61,6 +61,16 @@ func (ac AccessControl) toAccessControlChange() (AccessControlChange, bool) {
 	return AccessControlChange{}, false
 }
 
+// owner returns the direct IS_OWNER entry, which is set only for jobs
+func (oa ObjectACL) owner() (AccessControlChange, bool) {
+	for _, ac := range oa.AccessControlList {
+		if change, direct := ac.toAccessControlChange(); direct && change.PermissionLevel == "IS_OWNER" {
+			return change, true
+		}
+	}
+	return AccessControlChange{}, false
+}
+
 func (ac AccessControl) String() string {
 	return fmt.Sprintf("%s%s%s%v", ac.GroupName, ac.UserName, ac.ServicePrincipalName, ac.AllPermissions)
 }
@@ -160,15 +170,24 @@ func (a PermissionsAPI) Update(objectID string, objectACL AccessControlChangeLis
 			}
 		}
 		if owners == 0 {
-			me, err := identity.NewUsersAPI(a.context, a.client).Me()
+			current, err := a.Read(objectID)
 			if err != nil {
 				return err
 			}
-			// add owner if it's missing, otherwise automated planning might be difficult
-			objectACL.AccessControlList = append(objectACL.AccessControlList, AccessControlChange{
-				UserName:        me.UserName,
-				PermissionLevel: "IS_OWNER",
-			})
+			// keep the current owner, as it might be managed by `owner` of databricks_job
+			owner, ok := current.owner()
+			if !ok {
+				me, err := identity.NewUsersAPI(a.context, a.client).Me()
+				if err != nil {
+					return err
+				}
+				// add owner if it's missing, otherwise automated planning might be difficult
+				owner = AccessControlChange{
+					UserName:        me.UserName,
+					PermissionLevel: "IS_OWNER",
+				}
+			}
+			objectACL.AccessControlList = append(objectACL.AccessControlList, owner)
 		}
 	}
 	return a.put(objectID, objectACL)
@@ -176,6 +195,11 @@ func (a PermissionsAPI) Update(objectID string, objectACL AccessControlChangeLis
 
 // Delete gracefully removes permissions. Technically, it's using method named SetOrDelete, but here we do more
 func (a PermissionsAPI) Delete(objectID string) error {
+	return a.delete(objectID, false)
+}
+
+// delete removes permissions and either reverts job ownership to the creator or keeps the current owner
+func (a PermissionsAPI) delete(objectID string, keepJobOwner bool) error {
 	objectACL, err := a.Read(objectID)
 	if err != nil {
 		return err
@@ -190,14 +214,18 @@ func (a PermissionsAPI) Delete(objectID string) error {
 		}
 	}
 	if strings.HasPrefix(objectID, "/jobs") {
-		job, err := jobs.NewJobsAPI(a.context, a.client).Read(strings.ReplaceAll(objectID, "/jobs/", ""))
-		if err != nil {
-			return err
+		owner, ok := objectACL.owner()
+		if !keepJobOwner || !ok {
+			job, err := jobs.NewJobsAPI(a.context, a.client).Read(strings.ReplaceAll(objectID, "/jobs/", ""))
+			if err != nil {
+				return err
+			}
+			owner = AccessControlChange{
+				UserName:        job.CreatorUserName,
+				PermissionLevel: "IS_OWNER",
+			}
 		}
-		accl.AccessControlList = append(accl.AccessControlList, AccessControlChange{
-			UserName:        job.CreatorUserName,
-			PermissionLevel: "IS_OWNER",
-		})
+		accl.AccessControlList = append(accl.AccessControlList, owner)
 	}
 	return a.put(objectID, accl)
 }
@@ -299,6 +327,21 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
+// managesJobOwner tells if IS_OWNER is among configured permissions. Otherwise ownership of the job
+// is left intact, as it might be managed by `owner` of databricks_job. Imported permissions include the owner.
+func managesJobOwner(d *schema.ResourceData) bool {
+	accessControls := d.Get("access_control").(*schema.Set).List()
+	if len(accessControls) == 0 {
+		return true
+	}
+	for _, ac := range accessControls {
+		if ac.(map[string]interface{})["permission_level"] == "IS_OWNER" {
+			return true
+		}
+	}
+	return false
+}
+
 // ResourcePermissions definition
 func ResourcePermissions() *schema.Resource {
 	s := common.StructToSchema(PermissionsEntity{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
@@ -355,6 +398,15 @@ func ResourcePermissions() *schema.Resource {
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		if entity.ObjectType == "job" && !managesJobOwner(d) {
+			var withoutOwner []AccessControlChange
+			for _, change := range entity.AccessControlList {
+				if change.PermissionLevel != "IS_OWNER" {
+					withoutOwner = append(withoutOwner, change)
+				}
+			}
+			entity.AccessControlList = withoutOwner
+		}
 		if len(entity.AccessControlList) == 0 {
 			// empty "modifiable" access control list is the same as resource absence
 			d.SetId("")
@@ -438,7 +490,7 @@ func ResourcePermissions() *schema.Resource {
 			return readContext(ctx, d, m)
 		},
 		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-			err := NewPermissionsAPI(ctx, m).Delete(d.Id())
+			err := NewPermissionsAPI(ctx, m).delete(d.Id(), !managesJobOwner(d))
 			if common.IsMissing(err) {
 				log.Printf("[INFO] %s is already removed on backend", d.Id())
 				return nil
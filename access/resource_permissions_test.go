@@ -686,8 +686,9 @@ func TestResourcePermissionsUpdate(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			me,
 			{
-				Method:   http.MethodGet,
-				Resource: "/api/2.0/permissions/jobs/9",
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/permissions/jobs/9",
+				ReuseRequest: true,
 				Response: ObjectACL{
 					ObjectID:   "/jobs/9",
 					ObjectType: "job",
@@ -754,6 +755,132 @@ func TestResourcePermissionsUpdate(t *testing.T) {
 	assert.Equal(t, "CAN_VIEW", firstElem["permission_level"])
 }
 
+func TestResourcePermissionsUpdate_KeepsJobOwner(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			me,
+			{
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/permissions/jobs/9",
+				ReuseRequest: true,
+				Response: ObjectACL{
+					ObjectID:   "/jobs/9",
+					ObjectType: "job",
+					AccessControlList: []AccessControl{
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_VIEW",
+								},
+							},
+						},
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/permissions/jobs/9",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							UserName:        TestingUser,
+							PermissionLevel: "CAN_VIEW",
+						},
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							PermissionLevel:      "IS_OWNER",
+						},
+					},
+				},
+			},
+		},
+		InstanceState: map[string]string{
+			"job_id": "9",
+		},
+		HCL: `
+		job_id = 9
+
+		access_control {
+			user_name = "ben"
+			permission_level = "CAN_VIEW"
+		}
+		`,
+		Resource: ResourcePermissions(),
+		Update:   true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()), "owner must not be shown, when it's not managed")
+	assert.Equal(t, TestingUser, ac.List()[0].(map[string]interface{})["user_name"])
+}
+
+func TestResourcePermissionsDelete_KeepsJobOwner(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			me,
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/permissions/jobs/9",
+				Response: ObjectACL{
+					ObjectID:   "/jobs/9",
+					ObjectType: "job",
+					AccessControlList: []AccessControl{
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_VIEW",
+								},
+							},
+						},
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/permissions/jobs/9",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							PermissionLevel:      "IS_OWNER",
+						},
+					},
+				},
+			},
+		},
+		InstanceState: map[string]string{
+			"job_id":                            "9",
+			"access_control.#":                  "1",
+			"access_control.0.user_name":        TestingUser,
+			"access_control.0.permission_level": "CAN_VIEW",
+		},
+		Resource: ResourcePermissions(),
+		Delete:   true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/jobs/9", d.Id())
+}
+
 func permissionsTestHelper(t *testing.T,
 	cb func(permissionsAPI PermissionsAPI, user, group string,
 		ef func(string) PermissionsEntity)) {
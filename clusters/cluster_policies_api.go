@@ -0,0 +1,67 @@
+package clusters
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+)
+
+// ClusterPolicy defines cluster policy
+type ClusterPolicy struct {
+	PolicyID           string `json:"policy_id,omitempty"`
+	Name               string `json:"name"`
+	Definition         string `json:"definition"`
+	CreatedAtTimeStamp int64  `json:"created_at_timestamp"`
+}
+
+// ClusterPolicyCreate is the endity used for request
+type ClusterPolicyCreate struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+// NewClusterPoliciesAPI creates ClusterPoliciesAPI instance from provider meta
+// Creation and editing is available to admins only.
+func NewClusterPoliciesAPI(ctx context.Context, m interface{}) ClusterPoliciesAPI {
+	return ClusterPoliciesAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// ClusterPoliciesAPI struct for cluster policies API
+type ClusterPoliciesAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+type policyIDWrapper struct {
+	PolicyID string `json:"policy_id,omitempty" url:"policy_id,omitempty"`
+}
+
+// Create creates new cluster policy and sets PolicyID
+func (a ClusterPoliciesAPI) Create(clusterPolicy *ClusterPolicy) error {
+	var policyIDResponse = policyIDWrapper{}
+	err := a.client.Post(a.context, "/policies/clusters/create", clusterPolicy, &policyIDResponse)
+	if err != nil {
+		return err
+	}
+	clusterPolicy.PolicyID = policyIDResponse.PolicyID
+	return nil
+}
+
+// Edit will update an existing policy.
+// This may make some clusters governed by this policy invalid.
+// For such clusters the next cluster edit must provide a confirming configuration,
+// but otherwise they can continue to run.
+func (a ClusterPoliciesAPI) Edit(clusterPolicy *ClusterPolicy) error {
+	return a.client.Post(a.context, "/policies/clusters/edit", clusterPolicy, nil)
+}
+
+// Get returns cluster policy
+func (a ClusterPoliciesAPI) Get(policyID string) (policy ClusterPolicy, err error) {
+	err = a.client.Get(a.context, "/policies/clusters/get", policyIDWrapper{policyID}, &policy)
+	return
+}
+
+// Delete removes cluster policy
+func (a ClusterPoliciesAPI) Delete(policyID string) error {
+	return a.client.Post(a.context, "/policies/clusters/delete", policyIDWrapper{policyID}, nil)
+}
@@ -0,0 +1,481 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Rule types of cluster policy definition language.
+// See https://docs.databricks.com/administration-guide/clusters/policies.html#policy-definitions
+const (
+	PolicyRuleFixed     = "fixed"
+	PolicyRuleForbidden = "forbidden"
+	PolicyRuleAllowlist = "allowlist"
+	PolicyRuleBlocklist = "blocklist"
+	PolicyRuleRegex     = "regex"
+	PolicyRuleRange     = "range"
+	PolicyRuleUnlimited = "unlimited"
+)
+
+// ClusterPolicyRule limits a single attribute of a cluster
+type ClusterPolicyRule struct {
+	Type         string        `json:"type"`
+	Value        interface{}   `json:"value,omitempty"`
+	Values       []interface{} `json:"values,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
+	MinValue     *float64      `json:"minValue,omitempty"`
+	MaxValue     *float64      `json:"maxValue,omitempty"`
+	DefaultValue interface{}   `json:"defaultValue,omitempty"`
+	IsOptional   bool          `json:"isOptional,omitempty"`
+	Hidden       bool          `json:"hidden,omitempty"`
+}
+
+// ClusterPolicyDefinition maps attribute paths, like `autoscale.max_workers`,
+// `spark_conf.*` or `init_scripts.*.dbfs.destination`, to rules
+type ClusterPolicyDefinition map[string]ClusterPolicyRule
+
+// ClusterPolicyViolation describes an attribute, that doesn't conform to a policy
+type ClusterPolicyViolation struct {
+	// PolicyPath is the path in policy definition language, like `autoscale.max_workers`
+	PolicyPath string
+	// Path is the path in Terraform configuration, like `autoscale.0.max_workers`
+	Path    string
+	Message string
+}
+
+// ParseClusterPolicyDefinition parses JSON policy definition and validates its rules
+func ParseClusterPolicyDefinition(definition string) (ClusterPolicyDefinition, error) {
+	var pd ClusterPolicyDefinition
+	if err := json.Unmarshal([]byte(definition), &pd); err != nil {
+		return nil, fmt.Errorf("cannot parse policy definition: %w", err)
+	}
+	paths := make([]string, 0, len(pd))
+	for path := range pd {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := pd[path].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return pd, nil
+}
+
+// Validate checks if rule has all properties required by its type
+func (r ClusterPolicyRule) Validate() error {
+	switch r.Type {
+	case PolicyRuleFixed:
+		if r.Value == nil {
+			return fmt.Errorf("value is required for %s rule", r.Type)
+		}
+	case PolicyRuleForbidden, PolicyRuleUnlimited:
+	case PolicyRuleAllowlist, PolicyRuleBlocklist:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("values are required for %s rule", r.Type)
+		}
+	case PolicyRuleRegex:
+		if r.Pattern == "" {
+			return fmt.Errorf("pattern is required for %s rule", r.Type)
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	case PolicyRuleRange:
+		if r.MinValue == nil && r.MaxValue == nil {
+			return fmt.Errorf("minValue or maxValue is required for %s rule", r.Type)
+		}
+	default:
+		return fmt.Errorf("unknown rule type: %s", r.Type)
+	}
+	return nil
+}
+
+func policyValueString(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		// JSON numbers are decoded as float64 and shouldn't be printed as 1e+06
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+func (r ClusterPolicyRule) valuesContain(value string) bool {
+	for _, v := range r.Values {
+		if policyValueString(v) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ClusterPolicyRule) valuesString() string {
+	values := []string{}
+	for _, v := range r.Values {
+		values = append(values, policyValueString(v))
+	}
+	return strings.Join(values, ", ")
+}
+
+// check returns violation message for the value of present attribute
+func (r ClusterPolicyRule) check(value interface{}) string {
+	v := policyValueString(value)
+	switch r.Type {
+	case PolicyRuleFixed:
+		if v != policyValueString(r.Value) {
+			return fmt.Sprintf("must be %s", policyValueString(r.Value))
+		}
+	case PolicyRuleForbidden:
+		return "is forbidden"
+	case PolicyRuleAllowlist:
+		if !r.valuesContain(v) {
+			return fmt.Sprintf("must be one of: %s", r.valuesString())
+		}
+	case PolicyRuleBlocklist:
+		if r.valuesContain(v) {
+			return fmt.Sprintf("must not be one of: %s", r.valuesString())
+		}
+	case PolicyRuleRegex:
+		// the whole value has to match, just like on the backend
+		re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", r.Pattern))
+		if err != nil {
+			return fmt.Sprintf("invalid pattern: %s", err)
+		}
+		if !re.MatchString(v) {
+			return fmt.Sprintf("must match %s", r.Pattern)
+		}
+	case PolicyRuleRange:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "must be a number"
+		}
+		if r.MinValue != nil && f < *r.MinValue {
+			return fmt.Sprintf("must be at least %v", *r.MinValue)
+		}
+		if r.MaxValue != nil && f > *r.MaxValue {
+			return fmt.Sprintf("must be at most %v", *r.MaxValue)
+		}
+	}
+	return ""
+}
+
+// checkMissing returns violation message for the attribute, that is not set
+func (r ClusterPolicyRule) checkMissing(applyDefaults bool) string {
+	if r.Type == PolicyRuleFixed || r.Type == PolicyRuleForbidden || r.IsOptional {
+		// fixed values are set by the policy itself
+		return ""
+	}
+	if r.Type == PolicyRuleUnlimited && r.DefaultValue == nil {
+		// unlimited rule doesn't limit the value, so there's nothing to require
+		return ""
+	}
+	if r.DefaultValue != nil {
+		if applyDefaults {
+			return ""
+		}
+		return fmt.Sprintf("is required, set it to %s or set apply_policy_default_values = true",
+			policyValueString(r.DefaultValue))
+	}
+	return "is required"
+}
+
+// Evaluate checks cluster against policy definition, where cluster type is either
+// `all-purpose` or `job`. Attributes, that cannot be computed locally, like
+// `dbus_per_hour`, are not checked.
+func (pd ClusterPolicyDefinition) Evaluate(cluster Cluster, clusterType string) []ClusterPolicyViolation {
+	attrs := map[string]interface{}{}
+	flattenPolicyAttributes("", reflect.ValueOf(cluster), attrs)
+	if cluster.Autoscale != nil {
+		delete(attrs, "num_workers")
+	}
+	violations := []ClusterPolicyViolation{}
+	covered := map[string]bool{}
+	// explicit rules take precedence over wildcards
+	for policyPath, rule := range pd {
+		if strings.Contains(policyPath, "*") {
+			continue
+		}
+		var path string
+		var message string
+		value, present := attrs[policyPath]
+		if policyPath == "cluster_type" {
+			path, value, present = "policy_id", clusterType, clusterType != ""
+		} else {
+			var ok bool
+			path, ok = terraformPolicyPath(policyPath)
+			if !ok {
+				log.Printf("[DEBUG] Not checking %s attribute of cluster policy", policyPath)
+				continue
+			}
+		}
+		covered[policyPath] = true
+		if present {
+			message = rule.check(value)
+		} else {
+			message = rule.checkMissing(cluster.ApplyPolicyDefaultValues)
+		}
+		if message != "" {
+			violations = append(violations, ClusterPolicyViolation{policyPath, path, message})
+		}
+	}
+	for pattern, rule := range pd {
+		if !strings.Contains(pattern, "*") {
+			continue
+		}
+		for policyPath, value := range attrs {
+			if covered[policyPath] || !policyPathMatches(pattern, policyPath) {
+				continue
+			}
+			path, ok := terraformPolicyPath(policyPath)
+			if !ok {
+				continue
+			}
+			if message := rule.check(value); message != "" {
+				violations = append(violations, ClusterPolicyViolation{policyPath, path, message})
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations
+}
+
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("json"), ",")
+	omitEmpty := len(tag) > 1 && tag[1] == "omitempty"
+	return tag[0], omitEmpty
+}
+
+// flattenPolicyAttributes converts cluster to attribute paths of policy definition language,
+// where nested structures are separated with dots and list elements have indexes
+func flattenPolicyAttributes(prefix string, rv reflect.Value, attrs map[string]interface{}) {
+	join := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			flattenPolicyAttributes(prefix, rv.Elem(), attrs)
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			name, omitEmpty := jsonFieldName(rv.Type().Field(i))
+			if name == "" || name == "-" {
+				continue
+			}
+			if omitEmpty && rv.Field(i).IsZero() {
+				continue
+			}
+			flattenPolicyAttributes(join(name), rv.Field(i), attrs)
+		}
+	case reflect.Map:
+		for _, k := range rv.MapKeys() {
+			flattenPolicyAttributes(join(k.String()), rv.MapIndex(k), attrs)
+		}
+	case reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			flattenPolicyAttributes(join(strconv.Itoa(i)), rv.Index(i), attrs)
+		}
+	default:
+		attrs[prefix] = rv.Interface()
+	}
+}
+
+// terraformPolicyPath converts policy attribute path to the path in Terraform configuration,
+// where nested blocks are lists of one element. Paths, that are not present in Cluster, are
+// not found.
+func terraformPolicyPath(policyPath string) (string, bool) {
+	path, _, ok := resolvePolicyPath(policyPath)
+	return path, ok
+}
+
+// resolvePolicyPath returns Terraform path and the type of cluster attribute
+func resolvePolicyPath(policyPath string) (string, reflect.Type, bool) {
+	rt := reflect.TypeOf(Cluster{})
+	parts := strings.Split(policyPath, ".")
+	path := []string{}
+	for i := 0; i < len(parts); i++ {
+		for rt.Kind() == reflect.Ptr {
+			rt = rt.Elem()
+		}
+		switch rt.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByJSONName(rt, parts[i])
+			if !ok {
+				return "", nil, false
+			}
+			path = append(path, parts[i])
+			rt = field.Type
+			if rt.Kind() == reflect.Ptr && rt.Elem().Kind() == reflect.Struct {
+				path = append(path, "0")
+			}
+		case reflect.Map:
+			// keys of spark_conf and similar maps may have dots in them
+			path = append(path, strings.Join(parts[i:], "."))
+			return strings.Join(path, "."), rt.Elem(), true
+		case reflect.Slice:
+			path = append(path, parts[i])
+			rt = rt.Elem()
+		default:
+			return "", nil, false
+		}
+	}
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return strings.Join(path, "."), rt, true
+}
+
+// virtual attributes are not part of cluster specification, but can be limited by policy.
+// See https://docs.databricks.com/administration-guide/clusters/policies.html#virtual-attribute-paths
+var virtualPolicyAttributes = map[string]reflect.Kind{
+	"cluster_type":            reflect.String,
+	"dbus_per_hour":           reflect.Float64,
+	"cluster_log_conf.type":   reflect.String,
+	"cluster_log_conf.path":   reflect.String,
+	"cluster_log_conf.region": reflect.String,
+}
+
+func policyAttributeKind(policyPath string) (reflect.Kind, bool) {
+	if kind, ok := virtualPolicyAttributes[policyPath]; ok {
+		return kind, true
+	}
+	_, rt, ok := resolvePolicyPath(policyPath)
+	if !ok {
+		return reflect.Invalid, false
+	}
+	return rt.Kind(), true
+}
+
+// ValidatePolicyPath checks if attribute path, like `autoscale.max_workers` or `spark_conf.*`,
+// is present in cluster specification or is one of virtual attributes, like `dbus_per_hour`
+func ValidatePolicyPath(policyPath string) error {
+	if _, ok := policyAttributeKind(policyPath); !ok {
+		return fmt.Errorf("unknown cluster attribute: %s", policyPath)
+	}
+	return nil
+}
+
+// ParsePolicyRuleValue converts string representation of a rule value to the type of
+// cluster attribute, so that numbers and booleans are not quoted in policy definition
+func ParsePolicyRuleValue(policyPath, value string) (interface{}, error) {
+	kind, ok := policyAttributeKind(policyPath)
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster attribute: %s", policyPath)
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not an integer", value)
+		}
+		return v, nil
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a number", value)
+		}
+		return v, nil
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a boolean", value)
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+func fieldByJSONName(rt reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if fieldName, _ := jsonFieldName(field); fieldName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// policyPathMatches checks path against pattern, where `*` matches index of list element
+// and a trailing `*` matches any key of a map, like in `spark_conf.*` or `custom_tags.*`
+func policyPathMatches(pattern, path string) bool {
+	patternParts := strings.Split(pattern, ".")
+	pathParts := strings.Split(path, ".")
+	for i, part := range patternParts {
+		if i >= len(pathParts) {
+			return false
+		}
+		if part != "*" {
+			if part != pathParts[i] {
+				return false
+			}
+			continue
+		}
+		if i == len(patternParts)-1 {
+			return true
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
+
+// ValidateClusterPolicy checks cluster against its policy during plan, so that violations
+// are reported per attribute instead of failing on apply. Prefix is the path of the cluster
+// block in Terraform configuration, like `new_cluster.0.`. Attributes, that are not yet
+// known, are not checked.
+func ValidateClusterPolicy(ctx context.Context, d *schema.ResourceDiff, c interface{},
+	cluster Cluster, prefix, clusterType string) error {
+	if cluster.PolicyID == "" || !d.NewValueKnown(prefix+"policy_id") {
+		return nil
+	}
+	client := c.(*common.DatabricksClient)
+	if client.Host == "" {
+		log.Printf("[WARN] cannot validate cluster policy, because host is not known yet")
+		return nil
+	}
+	policy, err := NewClusterPoliciesAPI(ctx, client).Get(cluster.PolicyID)
+	if err != nil {
+		// validation is advisory, so deleted policy or transient error don't fail the plan
+		log.Printf("[WARN] cannot validate cluster policy %s: %v", cluster.PolicyID, err)
+		return nil
+	}
+	definition, err := ParseClusterPolicyDefinition(policy.Definition)
+	if err != nil {
+		return fmt.Errorf("policy %s: %w", policy.Name, err)
+	}
+	problems := []string{}
+	for _, violation := range definition.Evaluate(cluster, clusterType) {
+		path := prefix + violation.Path
+		if !isPathKnown(d, path) {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s: %s", path, violation.Message))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cluster doesn't conform to %s policy:\n%s",
+		policy.Name, strings.Join(problems, "\n"))
+}
+
+func isPathKnown(d *schema.ResourceDiff, path string) bool {
+	parts := strings.Split(path, ".")
+	for i := range parts {
+		if !d.NewValueKnown(strings.Join(parts[:i+1], ".")) {
+			return false
+		}
+	}
+	return true
+}
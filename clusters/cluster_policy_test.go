@@ -0,0 +1,269 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violationsOf(t *testing.T, definition string, cluster Cluster, clusterType string) map[string]string {
+	pd, err := ParseClusterPolicyDefinition(definition)
+	require.NoError(t, err)
+	res := map[string]string{}
+	for _, v := range pd.Evaluate(cluster, clusterType) {
+		res[v.Path] = v.Message
+	}
+	return res
+}
+
+func TestClusterPolicyEvaluate(t *testing.T) {
+	definition := `{
+		"spark_version": {"type": "regex", "pattern": "7\\.[0-9]+\\.x-scala.*"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+		"driver_node_type_id": {"type": "blocklist", "values": ["i3.16xlarge"]},
+		"autotermination_minutes": {"type": "fixed", "value": 20, "hidden": true},
+		"autoscale.max_workers": {"type": "range", "minValue": 1, "maxValue": 10},
+		"custom_tags.Team": {"type": "unlimited"},
+		"spark_conf.spark.databricks.cluster.profile": {"type": "forbidden"},
+		"aws_attributes.availability": {"type": "fixed", "value": "SPOT_WITH_FALLBACK"},
+		"instance_pool_id": {"type": "forbidden", "hidden": true},
+		"dbus_per_hour": {"type": "range", "maxValue": 10},
+		"cluster_type": {"type": "fixed", "value": "all-purpose"}
+	}`
+	assert.Equal(t, map[string]string{}, violationsOf(t, definition, Cluster{
+		SparkVersion:           "7.3.x-scala2.12",
+		NodeTypeID:             "i3.xlarge",
+		DriverNodeTypeID:       "i3.2xlarge",
+		AutoterminationMinutes: 20,
+		Autoscale: &AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 10,
+		},
+		CustomTags: map[string]string{
+			"Team": "data",
+		},
+	}, "all-purpose"))
+
+	assert.Equal(t, map[string]string{
+		"spark_version":                               "must match 7\\.[0-9]+\\.x-scala.*",
+		"node_type_id":                                "must be one of: i3.xlarge, i3.2xlarge",
+		"driver_node_type_id":                         "must not be one of: i3.16xlarge",
+		"autotermination_minutes":                     "must be 20",
+		"autoscale.0.max_workers":                     "must be at most 10",
+		"spark_conf.spark.databricks.cluster.profile": "is forbidden",
+		"aws_attributes.0.availability":               "must be SPOT_WITH_FALLBACK",
+		"policy_id":                                   "must be all-purpose",
+	}, violationsOf(t, definition, Cluster{
+		SparkVersion:           "8.3.x-scala2.12",
+		NodeTypeID:             "m4.large",
+		DriverNodeTypeID:       "i3.16xlarge",
+		AutoterminationMinutes: 60,
+		Autoscale: &AutoScale{
+			MinWorkers: 1,
+			MaxWorkers: 100,
+		},
+		SparkConf: map[string]string{
+			"spark.databricks.cluster.profile": "serverless",
+		},
+		AwsAttributes: &AwsAttributes{
+			Availability: "ON_DEMAND",
+		},
+	}, "job"))
+}
+
+func TestClusterPolicyEvaluate_OptionalAndDefaults(t *testing.T) {
+	definition := `{
+		"spark_version": {"type": "unlimited"},
+		"driver_node_type_id": {"type": "unlimited"},
+		"node_type_id": {"type": "allowlist", "values": ["i3.xlarge"], "isOptional": true},
+		"autotermination_minutes": {"type": "range", "maxValue": 60, "defaultValue": 30},
+		"num_workers": {"type": "range", "minValue": 1}
+	}`
+	cluster := Cluster{
+		SparkVersion: "7.3.x-scala2.12",
+		Autoscale: &AutoScale{
+			MaxWorkers: 2,
+		},
+	}
+	assert.Equal(t, map[string]string{
+		"autotermination_minutes": "is required, set it to 30 or set apply_policy_default_values = true",
+		"num_workers":             "is required",
+	}, violationsOf(t, definition, cluster, "all-purpose"))
+
+	cluster.ApplyPolicyDefaultValues = true
+	cluster.NumWorkers = 2
+	cluster.Autoscale = nil
+	assert.Equal(t, map[string]string{}, violationsOf(t, definition, cluster, "all-purpose"))
+}
+
+func TestClusterPolicyEvaluate_Wildcards(t *testing.T) {
+	definition := `{
+		"spark_conf.*": {"type": "blocklist", "values": ["true"]},
+		"spark_conf.spark.sql.adaptive.enabled": {"type": "fixed", "value": true},
+		"custom_tags.*": {"type": "regex", "pattern": "[a-z]+"},
+		"init_scripts.*.dbfs.destination": {"type": "regex", "pattern": "dbfs:/init/.*"}
+	}`
+	assert.Equal(t, map[string]string{
+		"spark_conf.spark.speculation":      "must not be one of: true",
+		"custom_tags.Team":                  "must match [a-z]+",
+		"init_scripts.1.dbfs.0.destination": "must match dbfs:/init/.*",
+	}, violationsOf(t, definition, Cluster{
+		SparkVersion: "7.3.x-scala2.12",
+		SparkConf: map[string]string{
+			"spark.sql.adaptive.enabled": "true",
+			"spark.speculation":          "true",
+		},
+		CustomTags: map[string]string{
+			"Team":  "Data",
+			"Owner": "someone",
+		},
+		InitScripts: []InitScriptStorageInfo{
+			{
+				Dbfs: &DbfsStorageInfo{
+					Destination: "dbfs:/init/a.sh",
+				},
+			},
+			{
+				Dbfs: &DbfsStorageInfo{
+					Destination: "dbfs:/tmp/b.sh",
+				},
+			},
+		},
+	}, "all-purpose"))
+}
+
+func TestParseClusterPolicyDefinition_Errors(t *testing.T) {
+	for definition, expected := range map[string]string{
+		`{`:                                        "cannot parse policy definition: unexpected end of JSON input",
+		`{"a": {"type": "something"}}`:             "a: unknown rule type: something",
+		`{"a": {"type": "fixed"}}`:                 "a: value is required for fixed rule",
+		`{"a": {"type": "allowlist"}}`:             "a: values are required for allowlist rule",
+		`{"a": {"type": "regex"}}`:                 "a: pattern is required for regex rule",
+		`{"a": {"type": "regex", "pattern": "("}}`: "a: invalid pattern: error parsing regexp: missing closing ): `(`",
+		`{"a": {"type": "range"}}`:                 "a: minValue or maxValue is required for range rule",
+	} {
+		_, err := ParseClusterPolicyDefinition(definition)
+		assert.EqualError(t, err, expected, definition)
+	}
+}
+
+func TestTerraformPolicyPath(t *testing.T) {
+	for policyPath, expected := range map[string]string{
+		"autoscale.min_workers":             "autoscale.0.min_workers",
+		"spark_conf.spark.databricks.repl":  "spark_conf.spark.databricks.repl",
+		"init_scripts.0.s3.destination":     "init_scripts.0.s3.0.destination",
+		"docker_image.basic_auth.username":  "docker_image.0.basic_auth.0.username",
+		"cluster_log_conf.dbfs.destination": "cluster_log_conf.0.dbfs.0.destination",
+		"ssh_public_keys.1":                 "ssh_public_keys.1",
+		"dbus_per_hour":                     "",
+		"autotermination_minutes.foo":       "",
+	} {
+		path, _ := terraformPolicyPath(policyPath)
+		assert.Equal(t, expected, path, policyPath)
+	}
+}
+
+func TestResourceClusterCreate_PolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Team",
+					Definition: `{
+						"autotermination_minutes": {"type": "range", "maxValue": 30},
+						"custom_tags.Team": {"type": "fixed", "value": "data"},
+						"instance_pool_id": {"type": "allowlist", "values": ["pool-1"]}
+					}`,
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Create:   true,
+		HCL: `
+		policy_id = "abc"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		custom_tags = {
+			"Team" = "sales"
+		}`,
+	}.ExpectError(t, "cluster doesn't conform to Team policy:\n"+
+		"autotermination_minutes: must be at most 30\n"+
+		"custom_tags.Team: must be data\n"+
+		"instance_pool_id: is required")
+}
+
+func TestResourceClusterCreate_PolicyNotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Status:   404,
+				Response: common.APIError{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Can't find a cluster policy with id: abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Status:   400,
+				Response: common.APIError{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Cluster policy abc does not exist",
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Create:   true,
+		HCL: `
+		policy_id = "abc"
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1`,
+	}.ExpectError(t, "Cluster policy abc does not exist")
+}
+
+func TestParsePolicyRuleValue(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		value    string
+		expected interface{}
+		err      string
+	}{
+		{"autotermination_minutes", "20", int64(20), ""},
+		{"autotermination_minutes", "20m", nil, "20m is not an integer"},
+		{"dbus_per_hour", "10.5", 10.5, ""},
+		{"enable_elastic_disk", "true", true, ""},
+		{"enable_elastic_disk", "yes", nil, "yes is not a boolean"},
+		{"spark_conf.spark.speculation", "true", "true", ""},
+		{"cluster_type", "job", "job", ""},
+		{"aws_attributes.first_on_demand", "1", int64(1), ""},
+		{"init_scripts.*.dbfs.destination", "dbfs:/a", "dbfs:/a", ""},
+		{"autotermination_mins", "20", nil, "unknown cluster attribute: autotermination_mins"},
+	} {
+		v, err := ParsePolicyRuleValue(tc.path, tc.value)
+		if tc.err != "" {
+			assert.EqualError(t, err, tc.err, tc.path)
+			continue
+		}
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.expected, v, tc.path)
+	}
+}
+
+func TestValidatePolicyPath(t *testing.T) {
+	assert.NoError(t, ValidatePolicyPath("custom_tags.*"))
+	assert.NoError(t, ValidatePolicyPath("docker_image.basic_auth.password"))
+	assert.NoError(t, ValidatePolicyPath("cluster_log_conf.type"))
+	assert.NoError(t, ValidatePolicyPath("cluster_log_conf.path"))
+	assert.EqualError(t, ValidatePolicyPath("docker_image.auth"),
+		"unknown cluster attribute: docker_image.auth")
+}
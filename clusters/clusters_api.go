@@ -336,15 +336,16 @@ type Cluster struct {
 	EnableElasticDisk         bool       `json:"enable_elastic_disk,omitempty" tf:"computed"`
 	EnableLocalDiskEncryption bool       `json:"enable_local_disk_encryption,omitempty" tf:"computed"`
 
-	NodeTypeID             string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
-	DriverNodeTypeID       string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
-	InstancePoolID         string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
-	DriverInstancePoolID   string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
-	PolicyID               string           `json:"policy_id,omitempty"`
-	AwsAttributes          *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AzureAttributes        *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	GcpAttributes          *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
-	AutoterminationMinutes int32            `json:"autotermination_minutes,omitempty"`
+	NodeTypeID               string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
+	DriverNodeTypeID         string           `json:"driver_node_type_id,omitempty" tf:"group:node_type,computed"`
+	InstancePoolID           string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
+	DriverInstancePoolID     string           `json:"driver_instance_pool_id,omitempty" tf:"group:node_type,computed"`
+	PolicyID                 string           `json:"policy_id,omitempty"`
+	ApplyPolicyDefaultValues bool             `json:"apply_policy_default_values,omitempty"`
+	AwsAttributes            *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AzureAttributes          *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	GcpAttributes            *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id,suppress_diff"`
+	AutoterminationMinutes   int32            `json:"autotermination_minutes,omitempty"`
 
 	SparkConf    map[string]string `json:"spark_conf,omitempty"`
 	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
@@ -444,7 +445,7 @@ type ClusterInfo struct {
 	LastStateLossTime         int64              `json:"last_state_loss_time,omitempty"`
 	LastActivityTime          int64              `json:"last_activity_time,omitempty"`
 	ClusterMemoryMb           int64              `json:"cluster_memory_mb,omitempty"`
-	ClusterCores              float32            `json:"cluster_cores,omitempty"`
+	ClusterCores              float64            `json:"cluster_cores,omitempty"`
 	DefaultTags               map[string]string  `json:"default_tags"`
 	ClusterLogStatus          *LogSyncStatus     `json:"cluster_log_status,omitempty"`
 	TerminationReason         *TerminationReason `json:"termination_reason,omitempty"`
@@ -715,7 +716,11 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 	curPos := len(eventsResponse.Events)
 	copy(events[startPos:curPos], eventsResponse.Events)
 	for curPos < totalCount && eventsResponse.NextPage != nil {
-		err := a.client.Post(a.context, "/clusters/events", eventsResponse.NextPage, &eventsResponse)
+		nextPage := eventsResponse.NextPage
+		// fresh response is required, as decoding into the previous one
+		// would overwrite details of already returned events
+		eventsResponse = EventsResponse{}
+		err := a.client.Post(a.context, "/clusters/events", nextPage, &eventsResponse)
 		if err != nil {
 			return nil, err
 		}
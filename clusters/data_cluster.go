@@ -0,0 +1,70 @@
+package clusters
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceCluster returns information about cluster specified by ID or unique name
+func DataSourceCluster() *schema.Resource {
+	type clusterData struct {
+		ClusterID   string       `json:"cluster_id,omitempty" tf:"computed"`
+		ClusterName string       `json:"cluster_name,omitempty" tf:"computed"`
+		ClusterInfo *ClusterInfo `json:"cluster_info,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(clusterData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["cluster_id"].ExactlyOneOf = []string{"cluster_id", "cluster_name"}
+		s["cluster_name"].ExactlyOneOf = []string{"cluster_id", "cluster_name"}
+		common.ComputedSchema(s["cluster_info"].Elem.(*schema.Resource).Schema)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this clusterData
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clustersAPI := NewClustersAPI(ctx, m)
+			if this.ClusterID != "" {
+				ci, err := clustersAPI.Get(this.ClusterID)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				this.ClusterInfo = &ci
+			} else {
+				clusters, err := clustersAPI.List()
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				for _, ci := range clusters {
+					if ci.ClusterName != this.ClusterName {
+						continue
+					}
+					if this.ClusterInfo != nil {
+						return diag.Errorf("there is more than one cluster with name '%s'",
+							this.ClusterName)
+					}
+					found := ci
+					this.ClusterInfo = &found
+				}
+				if this.ClusterInfo == nil {
+					return diag.Errorf("there is no cluster with name '%s'", this.ClusterName)
+				}
+			}
+			this.ClusterID = this.ClusterInfo.ClusterID
+			this.ClusterName = this.ClusterInfo.ClusterName
+			d.SetId(this.ClusterID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,323 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// NodeTypePrice holds hourly VM prices and DBU consumption of a node type
+type NodeTypePrice struct {
+	NodeTypeID    string  `json:"node_type_id"`
+	DBUPerHour    float64 `json:"dbu_per_hour"`
+	OnDemandPrice float64 `json:"on_demand_price,omitempty"`
+	SpotPrice     float64 `json:"spot_price,omitempty"`
+}
+
+// PriceTable is supplied by user, as prices depend on the contract, region and tier
+type PriceTable struct {
+	DBUPrice       float64         `json:"dbu_price"`
+	PhotonDBUPrice float64         `json:"photon_dbu_price,omitempty"`
+	NodeTypes      []NodeTypePrice `json:"node_types" tf:"alias:node_type"`
+}
+
+func (pt PriceTable) nodeType(nodeTypeID string) (NodeTypePrice, error) {
+	for _, nt := range pt.NodeTypes {
+		if nt.NodeTypeID == nodeTypeID {
+			return nt, nil
+		}
+	}
+	return NodeTypePrice{}, fmt.Errorf("%s is not in the price table", nodeTypeID)
+}
+
+// CostComponent is a part of estimated cost, like VMs of workers or DBUs of driver
+type CostComponent struct {
+	Component      string  `json:"component"`
+	NodeTypeID     string  `json:"node_type_id"`
+	NodesMin       int32   `json:"nodes_min"`
+	NodesMax       int32   `json:"nodes_max"`
+	HourlyCostMin  float64 `json:"hourly_cost_min"`
+	HourlyCostMax  float64 `json:"hourly_cost_max"`
+	MonthlyCostMin float64 `json:"monthly_cost_min"`
+	MonthlyCostMax float64 `json:"monthly_cost_max"`
+}
+
+// ClusterCostEstimate is the cluster specification and its estimated cost
+type ClusterCostEstimate struct {
+	NodeTypeID              string      `json:"node_type_id,omitempty" tf:"computed"`
+	DriverNodeTypeID        string      `json:"driver_node_type_id,omitempty" tf:"computed"`
+	InstancePoolID          string      `json:"instance_pool_id,omitempty"`
+	DriverInstancePoolID    string      `json:"driver_instance_pool_id,omitempty"`
+	NumWorkers              int32       `json:"num_workers,omitempty"`
+	Autoscale               *AutoScale  `json:"autoscale,omitempty"`
+	Photon                  bool        `json:"photon,omitempty"`
+	Availability            string      `json:"availability,omitempty" tf:"default:ON_DEMAND"`
+	UsePreemptibleExecutors bool        `json:"use_preemptible_executors,omitempty"`
+	FirstOnDemand           int32       `json:"first_on_demand,omitempty" tf:"default:1"`
+	HoursPerMonth           float64     `json:"hours_per_month,omitempty" tf:"default:730"`
+	PriceTable              *PriceTable `json:"price_table,omitempty"`
+	PriceTableFile          string      `json:"price_table_file,omitempty"`
+
+	HourlyCostMin  float64         `json:"hourly_cost_min,omitempty" tf:"computed"`
+	HourlyCostMax  float64         `json:"hourly_cost_max,omitempty" tf:"computed"`
+	MonthlyCostMin float64         `json:"monthly_cost_min,omitempty" tf:"computed"`
+	MonthlyCostMax float64         `json:"monthly_cost_max,omitempty" tf:"computed"`
+	Breakdown      []CostComponent `json:"breakdown,omitempty" tf:"computed"`
+}
+
+func (e *ClusterCostEstimate) workers() (int32, int32) {
+	if e.Autoscale != nil {
+		return e.Autoscale.MinWorkers, e.Autoscale.MaxWorkers
+	}
+	return e.NumWorkers, e.NumWorkers
+}
+
+func (e *ClusterCostEstimate) loadPriceTable() error {
+	if e.PriceTableFile == "" {
+		return nil
+	}
+	raw, err := ioutil.ReadFile(e.PriceTableFile)
+	if err != nil {
+		return err
+	}
+	e.PriceTable = &PriceTable{}
+	if err = json.Unmarshal(raw, e.PriceTable); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", e.PriceTableFile, err)
+	}
+	return nil
+}
+
+// availability returns AWS availability, that has the same pricing on other clouds:
+// Azure spot instances are priced the same way and preemptible executors on GCP
+// fall back to on-demand instances
+func (e *ClusterCostEstimate) availability() string {
+	if e.UsePreemptibleExecutors {
+		return AwsAvailabilitySpotWithFallback
+	}
+	return strings.TrimSuffix(e.Availability, "_AZURE")
+}
+
+// vmPrices returns minimal and maximal price of a node, that could be a spot instance
+func (e *ClusterCostEstimate) vmPrices(nt NodeTypePrice, onDemand bool) (float64, float64, error) {
+	if onDemand || e.availability() == AwsAvailabilityOnDemand {
+		return nt.OnDemandPrice, nt.OnDemandPrice, nil
+	}
+	if nt.SpotPrice == 0 {
+		return 0, 0, fmt.Errorf("spot price of %s is not in the price table", nt.NodeTypeID)
+	}
+	if e.availability() == AwsAvailabilitySpotWithFallback {
+		return nt.SpotPrice, nt.OnDemandPrice, nil
+	}
+	return nt.SpotPrice, nt.SpotPrice, nil
+}
+
+func (e *ClusterCostEstimate) addComponent(name, nodeTypeID string,
+	nodesMin, nodesMax int32, hourlyMin, hourlyMax float64) {
+	if nodesMax == 0 {
+		return
+	}
+	e.Breakdown = append(e.Breakdown, CostComponent{
+		Component:      name,
+		NodeTypeID:     nodeTypeID,
+		NodesMin:       nodesMin,
+		NodesMax:       nodesMax,
+		HourlyCostMin:  hourlyMin,
+		HourlyCostMax:  hourlyMax,
+		MonthlyCostMin: hourlyMin * e.HoursPerMonth,
+		MonthlyCostMax: hourlyMax * e.HoursPerMonth,
+	})
+	e.HourlyCostMin += hourlyMin
+	e.HourlyCostMax += hourlyMax
+}
+
+// Estimate calculates hourly and monthly costs of driver and workers. Driver and
+// first_on_demand-1 workers are on-demand instances, the rest of workers may be spot.
+func (e *ClusterCostEstimate) Estimate() error {
+	if e.PriceTable == nil {
+		return fmt.Errorf("price_table or price_table_file is required")
+	}
+	dbuPrice := e.PriceTable.DBUPrice
+	if e.Photon {
+		if e.PriceTable.PhotonDBUPrice == 0 {
+			return fmt.Errorf("photon_dbu_price is required in the price table")
+		}
+		dbuPrice = e.PriceTable.PhotonDBUPrice
+	}
+	if e.DriverNodeTypeID == "" {
+		e.DriverNodeTypeID = e.NodeTypeID
+	}
+	driver, err := e.PriceTable.nodeType(e.DriverNodeTypeID)
+	if err != nil {
+		return err
+	}
+	worker, err := e.PriceTable.nodeType(e.NodeTypeID)
+	if err != nil {
+		return err
+	}
+	e.Breakdown = []CostComponent{}
+	e.HourlyCostMin, e.HourlyCostMax = 0, 0
+	driverMin, driverMax, err := e.vmPrices(driver, e.FirstOnDemand > 0)
+	if err != nil {
+		return err
+	}
+	e.addComponent("driver_vm", driver.NodeTypeID, 1, 1, driverMin, driverMax)
+	driverDBUs := driver.DBUPerHour * dbuPrice
+	e.addComponent("driver_dbu", driver.NodeTypeID, 1, 1, driverDBUs, driverDBUs)
+
+	workersMin, workersMax := e.workers()
+	onDemandWorkers := e.FirstOnDemand - 1
+	if e.availability() == AwsAvailabilityOnDemand {
+		onDemandWorkers = workersMax
+	}
+	if onDemandWorkers < 0 {
+		onDemandWorkers = 0
+	}
+	onDemandMin, onDemandMax := onDemandWorkers, onDemandWorkers
+	if onDemandMin > workersMin {
+		onDemandMin = workersMin
+	}
+	if onDemandMax > workersMax {
+		onDemandMax = workersMax
+	}
+	e.addComponent("workers_on_demand_vm", worker.NodeTypeID, onDemandMin, onDemandMax,
+		float64(onDemandMin)*worker.OnDemandPrice, float64(onDemandMax)*worker.OnDemandPrice)
+	spotMin, spotMax := workersMin-onDemandMin, workersMax-onDemandMax
+	if spotMax > 0 {
+		priceMin, priceMax, err := e.vmPrices(worker, false)
+		if err != nil {
+			return err
+		}
+		e.addComponent("workers_spot_vm", worker.NodeTypeID, spotMin, spotMax,
+			float64(spotMin)*priceMin, float64(spotMax)*priceMax)
+	}
+	workerDBUs := worker.DBUPerHour * dbuPrice
+	e.addComponent("workers_dbu", worker.NodeTypeID, workersMin, workersMax,
+		float64(workersMin)*workerDBUs, float64(workersMax)*workerDBUs)
+	e.MonthlyCostMin = e.HourlyCostMin * e.HoursPerMonth
+	e.MonthlyCostMax = e.HourlyCostMax * e.HoursPerMonth
+	return nil
+}
+
+type instancePoolNodeType struct {
+	NodeTypeID string `json:"node_type_id"`
+}
+
+// instancePoolNodeType returns node type of the instance pool. Instance Pools API is
+// called directly, because pools package depends on this one.
+func (a ClustersAPI) instancePoolNodeType(instancePoolID string) (string, error) {
+	var pool instancePoolNodeType
+	err := a.client.Get(a.context, "/instance-pools/get", map[string]string{
+		"instance_pool_id": instancePoolID,
+	}, &pool)
+	if err != nil {
+		return "", fmt.Errorf("instance pool %s: %w", instancePoolID, err)
+	}
+	return pool.NodeTypeID, nil
+}
+
+// resolveInstancePools finds node types of clusters, that are using instance pools.
+// Just like for clusters, driver uses the pool of workers, unless it's set explicitly.
+func (e *ClusterCostEstimate) resolveInstancePools(clustersAPI ClustersAPI) (err error) {
+	if e.InstancePoolID == "" {
+		if e.DriverInstancePoolID != "" {
+			return fmt.Errorf("driver_instance_pool_id requires instance_pool_id")
+		}
+		if e.NodeTypeID == "" {
+			return fmt.Errorf("node_type_id or instance_pool_id is required")
+		}
+		return nil
+	}
+	if e.NodeTypeID, err = clustersAPI.instancePoolNodeType(e.InstancePoolID); err != nil {
+		return err
+	}
+	if e.DriverInstancePoolID == "" || e.DriverInstancePoolID == e.InstancePoolID {
+		e.DriverNodeTypeID = e.NodeTypeID
+		return nil
+	}
+	e.DriverNodeTypeID, err = clustersAPI.instancePoolNodeType(e.DriverInstancePoolID)
+	return err
+}
+
+// checkNodeTypes verifies, that node types are available in the workspace. Listing
+// of node types is not always possible, so failures are not fatal.
+func (e *ClusterCostEstimate) checkNodeTypes(clustersAPI ClustersAPI) error {
+	list, err := clustersAPI.ListNodeTypes()
+	if err != nil || len(list.NodeTypes) == 0 {
+		log.Printf("[WARN] Cannot check node types: %v", err)
+		return nil
+	}
+	for _, nodeTypeID := range []string{e.NodeTypeID, e.DriverNodeTypeID} {
+		if nodeTypeID == "" {
+			continue
+		}
+		found := false
+		for _, nt := range list.NodeTypes {
+			if nt.NodeTypeID == nodeTypeID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("node type %s is not available in this workspace", nodeTypeID)
+		}
+	}
+	return nil
+}
+
+// DataSourceClusterCostEstimate estimates hourly and monthly cost of a cluster
+// based on user-supplied price table
+func DataSourceClusterCostEstimate() *schema.Resource {
+	s := common.StructToSchema(ClusterCostEstimate{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["num_workers"].ConflictsWith = []string{"autoscale"}
+		s["autoscale"].ConflictsWith = []string{"num_workers"}
+		s["price_table"].ExactlyOneOf = []string{"price_table", "price_table_file"}
+		s["price_table_file"].ExactlyOneOf = []string{"price_table", "price_table_file"}
+		s["node_type_id"].ConflictsWith = []string{"instance_pool_id"}
+		s["driver_node_type_id"].ConflictsWith = []string{"instance_pool_id"}
+		s["instance_pool_id"].ConflictsWith = []string{"node_type_id", "driver_node_type_id"}
+		s["availability"].ValidateFunc = validation.StringInSlice([]string{
+			AwsAvailabilityOnDemand, AwsAvailabilitySpot, AwsAvailabilitySpotWithFallback,
+			AzureAvailabilityOnDemand, AzureAvailabilitySpot, AzureAvailabilitySpotWithFallback,
+		}, false)
+		common.ComputedSchema(s["breakdown"].Elem.(*schema.Resource).Schema)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this ClusterCostEstimate
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err = this.loadPriceTable(); err != nil {
+				return diag.FromErr(err)
+			}
+			clustersAPI := NewClustersAPI(ctx, m)
+			if err = this.resolveInstancePools(clustersAPI); err != nil {
+				return diag.FromErr(err)
+			}
+			if err = this.checkNodeTypes(clustersAPI); err != nil {
+				return diag.FromErr(err)
+			}
+			if err = this.Estimate(); err != nil {
+				return diag.FromErr(err)
+			}
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.NodeTypeID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,227 @@
+package clusters
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var nodeTypesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/clusters/list-node-types",
+	ReuseRequest: true,
+	Response: NodeTypeList{
+		NodeTypes: []NodeType{
+			{
+				NodeTypeID: "i3.xlarge",
+				NumCores:   4,
+				MemoryMB:   31232,
+			},
+			{
+				NodeTypeID: "i3.2xlarge",
+				NumCores:   8,
+				MemoryMB:   62464,
+			},
+		},
+	},
+}
+
+const priceTableHCL = `
+price_table {
+	dbu_price = 0.5
+	photon_dbu_price = 1
+	node_type {
+		node_type_id = "i3.xlarge"
+		dbu_per_hour = 1
+		on_demand_price = 0.3
+		spot_price = 0.1
+	}
+	node_type {
+		node_type_id = "i3.2xlarge"
+		dbu_per_hour = 2
+		on_demand_price = 0.6
+	}
+}`
+
+func TestClusterCostEstimate_OnDemand(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{nodeTypesFixture},
+		Resource:    DataSourceClusterCostEstimate(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		node_type_id = "i3.xlarge"
+		driver_node_type_id = "i3.2xlarge"
+		num_workers = 2
+		hours_per_month = 100` + priceTableHCL,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// driver: 0.6 + 2*0.5, workers: 2*0.3 + 2*1*0.5
+	assert.InDelta(t, 3.2, d.Get("hourly_cost_min"), 0.0001)
+	assert.InDelta(t, 3.2, d.Get("hourly_cost_max"), 0.0001)
+	assert.InDelta(t, 320, d.Get("monthly_cost_max"), 0.0001)
+	assert.Equal(t, 4, d.Get("breakdown.#"))
+	assert.Equal(t, "workers_on_demand_vm", d.Get("breakdown.2.component"))
+	assert.Equal(t, 2, d.Get("breakdown.2.nodes_max"))
+	assert.InDelta(t, 60, d.Get("breakdown.2.monthly_cost_max"), 0.0001)
+}
+
+func TestClusterCostEstimate_SpotWithFallbackAutoscaling(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{nodeTypesFixture},
+		Resource:    DataSourceClusterCostEstimate(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		node_type_id = "i3.xlarge"
+		autoscale {
+			min_workers = 1
+			max_workers = 4
+		}
+		photon = true
+		availability = "SPOT_WITH_FALLBACK"
+		first_on_demand = 2` + priceTableHCL,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// driver: 0.3 + 1, one on-demand worker: 0.3, spot workers: 0..3 * 0.1..0.3,
+	// workers DBUs: 1..4 * 1
+	assert.InDelta(t, 2.6, d.Get("hourly_cost_min"), 0.0001)
+	assert.InDelta(t, 6.5, d.Get("hourly_cost_max"), 0.0001)
+	assert.InDelta(t, 6.5*730, d.Get("monthly_cost_max"), 0.0001)
+	assert.Equal(t, 5, d.Get("breakdown.#"))
+	assert.Equal(t, "workers_spot_vm", d.Get("breakdown.3.component"))
+	assert.Equal(t, 0, d.Get("breakdown.3.nodes_min"))
+	assert.Equal(t, 3, d.Get("breakdown.3.nodes_max"))
+}
+
+func TestClusterCostEstimate_PriceTableFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prices.json")
+	err := ioutil.WriteFile(file, []byte(`{
+		"dbu_price": 0.5,
+		"node_types": [
+			{"node_type_id": "i3.xlarge", "dbu_per_hour": 1, "on_demand_price": 0.3, "spot_price": 0.1}
+		]
+	}`), 0600)
+	require.NoError(t, err)
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{nodeTypesFixture},
+		Resource:    DataSourceClusterCostEstimate(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		node_type_id = "i3.xlarge"
+		num_workers = 3
+		availability = "SPOT"
+		price_table_file = "` + filepath.ToSlash(file) + `"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// driver: 0.3 + 0.5, workers: 3*0.1 + 3*0.5
+	assert.InDelta(t, 2.6, d.Get("hourly_cost_min"), 0.0001)
+	assert.InDelta(t, 2.6, d.Get("hourly_cost_max"), 0.0001)
+}
+
+func TestClusterCostEstimate_OtherClouds(t *testing.T) {
+	for _, availability := range []string{
+		`availability = "SPOT_WITH_FALLBACK_AZURE"`,
+		`use_preemptible_executors = true`,
+	} {
+		d, err := qa.ResourceFixture{
+			Fixtures:    []qa.HTTPFixture{nodeTypesFixture},
+			Resource:    DataSourceClusterCostEstimate(),
+			Read:        true,
+			NonWritable: true,
+			ID:          "_",
+			HCL: `
+			node_type_id = "i3.xlarge"
+			num_workers = 2
+			` + availability + priceTableHCL,
+		}.Apply(t)
+		require.NoError(t, err, err)
+		// driver: 0.3 + 0.5, spot workers: 2 * 0.1..0.3, workers DBUs: 2*0.5
+		assert.InDelta(t, 2.0, d.Get("hourly_cost_min"), 0.0001, availability)
+		assert.InDelta(t, 2.4, d.Get("hourly_cost_max"), 0.0001, availability)
+	}
+}
+
+func TestClusterCostEstimate_InstancePools(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=workers",
+				Response: instancePoolNodeType{
+					NodeTypeID: "i3.xlarge",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=driver",
+				Response: instancePoolNodeType{
+					NodeTypeID: "i3.2xlarge",
+				},
+			},
+			nodeTypesFixture,
+		},
+		Resource:    DataSourceClusterCostEstimate(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		instance_pool_id = "workers"
+		driver_instance_pool_id = "driver"
+		num_workers = 2` + priceTableHCL,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+	assert.Equal(t, "i3.2xlarge", d.Get("driver_node_type_id"))
+	// driver: 0.6 + 2*0.5, workers: 2*0.3 + 2*1*0.5
+	assert.InDelta(t, 3.2, d.Get("hourly_cost_max"), 0.0001)
+}
+
+func TestClusterCostEstimate_Errors(t *testing.T) {
+	for hcl, expected := range map[string]string{
+		`node_type_id = "m4.large"` + priceTableHCL: "node type m4.large is not available in this workspace",
+		`node_type_id = "i3.xlarge"
+		price_table {
+			dbu_price = 0.5
+			node_type {
+				node_type_id = "i3.2xlarge"
+				dbu_per_hour = 2
+			}
+		}`: "i3.xlarge is not in the price table",
+		`node_type_id = "i3.xlarge"
+		photon = true
+		price_table_file = "` + filepath.ToSlash(filepath.Join(t.TempDir(), "missing.json")) + `"`: "open ",
+		`node_type_id = "i3.2xlarge"
+		num_workers = 1
+		availability = "SPOT"` + priceTableHCL: "spot price of i3.2xlarge is not in the price table",
+		`num_workers = 1` + priceTableHCL: "node_type_id or instance_pool_id is required",
+		`node_type_id = "i3.xlarge"
+		driver_instance_pool_id = "driver"` + priceTableHCL: "driver_instance_pool_id requires instance_pool_id",
+	} {
+		_, err := qa.ResourceFixture{
+			Fixtures:    []qa.HTTPFixture{nodeTypesFixture},
+			Resource:    DataSourceClusterCostEstimate(),
+			Read:        true,
+			NonWritable: true,
+			ID:          "_",
+			HCL:         hcl,
+		}.Apply(t)
+		qa.AssertErrorStartsWith(t, err, expected)
+	}
+	err := (&ClusterCostEstimate{
+		NodeTypeID: "i3.xlarge",
+		Photon:     true,
+		PriceTable: &PriceTable{
+			DBUPrice: 0.5,
+		},
+	}).Estimate()
+	assert.EqualError(t, err, "photon_dbu_price is required in the price table")
+}
@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type clusterEventData struct {
+	Timestamp         int64              `json:"timestamp"`
+	Time              string             `json:"time"`
+	Type              string             `json:"type"`
+	CurrentNumWorkers int32              `json:"current_num_workers,omitempty"`
+	TargetNumWorkers  int32              `json:"target_num_workers,omitempty"`
+	ResizeCause       string             `json:"resize_cause,omitempty"`
+	TerminationReason *TerminationReason `json:"termination_reason,omitempty"`
+	User              string             `json:"user,omitempty"`
+}
+
+func newClusterEventData(event ClusterEvent) clusterEventData {
+	ed := clusterEventData{
+		Timestamp:         event.Timestamp,
+		Time:              time.Unix(0, event.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339),
+		Type:              string(event.Type),
+		CurrentNumWorkers: event.Details.CurrentNumWorkers,
+		TargetNumWorkers:  event.Details.TargetNumWorkers,
+		TerminationReason: event.Details.Reason,
+		User:              event.Details.User,
+	}
+	if event.Details.ResizeCause != nil {
+		ed.ResizeCause = string(*event.Details.ResizeCause)
+	}
+	return ed
+}
+
+func parseEventTime(name, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return t.UnixNano() / int64(time.Millisecond), nil
+}
+
+// DataSourceClusterEvents returns events of a cluster, like starts, terminations and resizes
+func DataSourceClusterEvents() *schema.Resource {
+	type clusterEventsData struct {
+		ClusterID  string             `json:"cluster_id"`
+		EventTypes []string           `json:"event_types,omitempty"`
+		StartTime  string             `json:"start_time,omitempty"`
+		EndTime    string             `json:"end_time,omitempty"`
+		Lookback   string             `json:"lookback,omitempty"`
+		Order      string             `json:"order,omitempty" tf:"default:DESC"`
+		Limit      int                `json:"limit,omitempty" tf:"default:50"`
+		Events     []clusterEventData `json:"events,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(clusterEventsData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["start_time"].ValidateFunc = validation.IsRFC3339Time
+		s["start_time"].ConflictsWith = []string{"lookback"}
+		s["end_time"].ValidateFunc = validation.IsRFC3339Time
+		s["lookback"].ConflictsWith = []string{"start_time"}
+		s["order"].ValidateFunc = validation.StringInSlice([]string{
+			string(SortAscending), string(SortDescending)}, false)
+		s["limit"].ValidateFunc = validation.IntAtLeast(1)
+		common.ComputedSchema(s["events"].Elem.(*schema.Resource).Schema)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this clusterEventsData
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			request := EventsRequest{
+				ClusterID: this.ClusterID,
+				Order:     SortOrder(this.Order),
+				MaxItems:  uint(this.Limit),
+				// page size is limited by the API
+				Limit: 500,
+			}
+			if this.Limit < 500 {
+				request.Limit = int64(this.Limit)
+			}
+			for _, eventType := range this.EventTypes {
+				request.EventTypes = append(request.EventTypes, ClusterEventType(eventType))
+			}
+			if request.StartTime, err = parseEventTime("start_time", this.StartTime); err != nil {
+				return diag.FromErr(err)
+			}
+			if request.EndTime, err = parseEventTime("end_time", this.EndTime); err != nil {
+				return diag.FromErr(err)
+			}
+			if this.Lookback != "" {
+				lookback, err := time.ParseDuration(this.Lookback)
+				if err != nil {
+					return diag.Errorf("lookback: %s", err)
+				}
+				request.StartTime = time.Now().Add(-lookback).UnixNano() / int64(time.Millisecond)
+			}
+			events, err := NewClustersAPI(ctx, m).Events(request)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Events = []clusterEventData{}
+			for _, event := range events {
+				this.Events = append(this.Events, newClusterEventData(event))
+			}
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.ClusterID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterEventsDataSource(t *testing.T) {
+	autoscale := ResizeCause("AUTOSCALE")
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					StartTime:  1609459200000,
+					EndTime:    1609545600000,
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypeTerminating, EvTypeResizing},
+					Limit:      3,
+				},
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Timestamp: 1609500000000,
+							Type:      EvTypeTerminating,
+							Details: EventDetails{
+								Reason: &TerminationReason{
+									Code: "CLOUD_PROVIDER_LAUNCH_FAILURE",
+									Type: "CLOUD_FAILURE",
+									Parameters: map[string]string{
+										"aws_error_message": "InsufficientInstanceCapacity",
+									},
+								},
+							},
+						},
+						{
+							ClusterID: "abc",
+							Timestamp: 1609490000000,
+							Type:      EvTypeResizing,
+							Details: EventDetails{
+								CurrentNumWorkers: 2,
+								TargetNumWorkers:  8,
+								ResizeCause:       &autoscale,
+							},
+						},
+					},
+					NextPage: &EventsRequest{
+						ClusterID: "abc",
+						Offset:    2,
+						Limit:     3,
+					},
+					TotalCount: 5,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID: "abc",
+					Offset:    2,
+					Limit:     3,
+				},
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Timestamp: 1609480000000,
+							Type:      EvTypeTerminating,
+							Details: EventDetails{
+								User: "someone@example.com",
+								Reason: &TerminationReason{
+									Code: "USER_REQUEST",
+								},
+							},
+						},
+						{
+							ClusterID: "abc",
+							Timestamp: 1609470000000,
+							Type:      EvTypeTerminating,
+						},
+					},
+					TotalCount: 5,
+				},
+			},
+		},
+		Resource:    DataSourceClusterEvents(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		cluster_id = "abc"
+		event_types = ["TERMINATING", "RESIZING"]
+		start_time = "2021-01-01T00:00:00Z"
+		end_time = "2021-01-02T00:00:00Z"
+		limit = 3`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 3, d.Get("events.#"))
+	assert.Equal(t, "2021-01-01T11:20:00Z", d.Get("events.0.time"))
+	assert.Equal(t, "CLOUD_PROVIDER_LAUNCH_FAILURE", d.Get("events.0.termination_reason.0.code"))
+	assert.Equal(t, "InsufficientInstanceCapacity",
+		d.Get("events.0.termination_reason.0.parameters.aws_error_message"))
+	assert.Equal(t, "AUTOSCALE", d.Get("events.1.resize_cause"))
+	assert.Equal(t, 8, d.Get("events.1.target_num_workers"))
+	assert.Equal(t, "someone@example.com", d.Get("events.2.user"))
+}
+
+func TestClusterEventsDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Status:   404,
+				Response: common.NotFound("Cluster abc does not exist"),
+			},
+		},
+		Resource:    DataSourceClusterEvents(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `cluster_id = "abc"`,
+	}.ExpectError(t, "Cluster abc does not exist")
+}
+
+func TestClusterEventsDataSource_InvalidLookback(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourceClusterEvents(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		cluster_id = "abc"
+		lookback = "a week"`,
+	}.ExpectError(t, "lookback: time: invalid duration \"a week\"")
+}
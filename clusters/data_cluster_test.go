@@ -0,0 +1,90 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterDataByID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Shared Autoscaling",
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateRunning,
+					AutoScale: &AutoScale{
+						MaxWorkers: 4,
+					},
+				},
+			},
+		},
+		Resource:    DataSourceCluster(),
+		HCL:         `cluster_id = "abc"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "abc",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Shared Autoscaling", d.Get("cluster_name"))
+	assert.Equal(t, 15, d.Get("cluster_info.0.autotermination_minutes"))
+	assert.Equal(t, "i3.xlarge", d.Get("cluster_info.0.node_type_id"))
+	assert.Equal(t, 4, d.Get("cluster_info.0.autoscale.0.max_workers"))
+	assert.Equal(t, "RUNNING", d.Get("cluster_info.0.state"))
+}
+
+func TestClusterDataByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Resource:    DataSourceCluster(),
+		HCL:         `cluster_name = "Personal"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", d.Id())
+	assert.Equal(t, "c", d.Get("cluster_id"))
+	assert.Equal(t, "first@example.com", d.Get("cluster_info.0.creator_user_name"))
+}
+
+func TestClusterDataByName_Duplicate(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: ClusterList{
+					Clusters: []ClusterInfo{
+						{ClusterID: "a", ClusterName: "Shared"},
+						{ClusterID: "b", ClusterName: "Shared"},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceCluster(),
+		HCL:         `cluster_name = "Shared"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "there is more than one cluster with name 'Shared'")
+}
+
+func TestClusterDataByName_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Resource:    DataSourceCluster(),
+		HCL:         `cluster_name = "Unknown"`,
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "there is no cluster with name 'Unknown'")
+}
@@ -0,0 +1,82 @@
+package clusters
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ClustersFilter is a wrapper for local filtering of clusters
+type ClustersFilter struct {
+	ClusterNameContains string            `json:"cluster_name_contains,omitempty"`
+	CustomTags          map[string]string `json:"custom_tags,omitempty"`
+	State               string            `json:"state,omitempty"`
+	CreatorUserName     string            `json:"creator_user_name,omitempty"`
+	IDs                 []string          `json:"ids,omitempty" tf:"computed,slice_set"`
+}
+
+// Matches tells if cluster fits the filter
+func (f ClustersFilter) Matches(ci ClusterInfo) bool {
+	if f.ClusterNameContains != "" && !strings.Contains(
+		strings.ToLower(ci.ClusterName), strings.ToLower(f.ClusterNameContains)) {
+		return false
+	}
+	for k, v := range f.CustomTags {
+		if ci.CustomTags[k] != v {
+			return false
+		}
+	}
+	if f.State != "" && string(ci.State) != f.State {
+		return false
+	}
+	if f.CreatorUserName != "" && !strings.EqualFold(ci.CreatorUserName, f.CreatorUserName) {
+		return false
+	}
+	return true
+}
+
+// DataSourceClusters returns IDs of clusters, that match given criteria
+func DataSourceClusters() *schema.Resource {
+	s := common.StructToSchema(ClustersFilter{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		// nolint once SDKv2 has Diagnostics-returning validators, change
+		s["state"].ValidateFunc = validation.StringInSlice([]string{
+			ClusterStatePending, ClusterStateRunning, ClusterStateRestarting,
+			ClusterStateResizing, ClusterStateTerminating, ClusterStateTerminated,
+			ClusterStateError, ClusterStateUnknown,
+		}, false)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var filter ClustersFilter
+			err := common.DataToStructPointer(d, s, &filter)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clusters, err := NewClustersAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			filter.IDs = []string{}
+			for _, ci := range clusters {
+				if filter.Matches(ci) {
+					filter.IDs = append(filter.IDs, ci.ClusterID)
+				}
+			}
+			sort.Strings(filter.IDs)
+			d.SetId("_")
+			err = d.Set("ids", filter.IDs)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
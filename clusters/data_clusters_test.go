@@ -0,0 +1,92 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var clustersListFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/clusters/list",
+	ReuseRequest: true,
+	Response: ClusterList{
+		Clusters: []ClusterInfo{
+			{
+				ClusterID:       "b",
+				ClusterName:     "Shared Autoscaling",
+				CreatorUserName: "first@example.com",
+				CustomTags:      map[string]string{"Team": "data"},
+				State:           ClusterStateRunning,
+			},
+			{
+				ClusterID:       "a",
+				ClusterName:     "Shared Pool",
+				CreatorUserName: "second@example.com",
+				CustomTags:      map[string]string{"Team": "data"},
+				State:           ClusterStateTerminated,
+			},
+			{
+				ClusterID:       "c",
+				ClusterName:     "Personal",
+				CreatorUserName: "first@example.com",
+				State:           ClusterStateRunning,
+			},
+		},
+	},
+}
+
+func TestClustersDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Resource:    DataSourceClusters(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		cluster_name_contains = "shared"
+		custom_tags = {
+			Team = "data"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, d.Get("ids").(*schema.Set).List())
+}
+
+func TestClustersDataSource_StateAndCreator(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Resource:    DataSourceClusters(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		state = "RUNNING"
+		creator_user_name = "FIRST@example.com"`,
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", "c"}, d.Get("ids").(*schema.Set).List())
+}
+
+func TestClustersDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Status:   500,
+				Response: common.APIError{
+					ErrorCode: "INVALID_STATE",
+					Message:   "Something went wrong",
+				},
+			},
+		},
+		Resource:    DataSourceClusters(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "Something went wrong")
+}
@@ -2,12 +2,16 @@ package clusters
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // NodeTypeRequest is a wrapper for local filtering of node types
@@ -22,6 +26,15 @@ type NodeTypeRequest struct {
 	PhotonDriverCapable   bool   `json:"photon_driver_capable,omitempty"`
 	IsIOCacheEnabled      bool   `json:"is_io_cache_enabled,omitempty"`
 	SupportPortForwarding bool   `json:"support_port_forwarding,omitempty"`
+	FamilyRegex           string `json:"family_regex,omitempty"`
+	ExcludeFamilyRegex    string `json:"exclude_family_regex,omitempty"`
+	MinLocalDiskGB        int32  `json:"min_local_disk_gb,omitempty"`
+	Graviton              bool   `json:"graviton,omitempty"`
+	Fleet                 bool   `json:"fleet,omitempty"`
+	ExcludeDeprecated     bool   `json:"exclude_deprecated,omitempty"`
+	ExcludeUnavailable    bool   `json:"exclude_unavailable,omitempty"`
+
+	Candidates []string `json:"candidates,omitempty" tf:"computed"`
 }
 
 // NodeTypeList contains a list of node types
@@ -97,6 +110,7 @@ type NodeType struct {
 	NodeInstanceType      *NodeInstanceType             `json:"node_instance_type,omitempty"`
 	PhotonWorkerCapable   bool                          `json:"photon_worker_capable,omitempty"`
 	PhotonDriverCapable   bool                          `json:"photon_driver_capable,omitempty"`
+	IsGraviton            bool                          `json:"is_graviton,omitempty"`
 }
 
 func defaultSmallestNodeType(a ClustersAPI) string {
@@ -114,58 +128,164 @@ func (a ClustersAPI) ListNodeTypes() (l NodeTypeList, err error) {
 	return
 }
 
-// GetSmallestNodeType returns smallest (or default) node type id given the criteria
-func (a ClustersAPI) GetSmallestNodeType(r NodeTypeRequest) string {
-	list, _ := a.ListNodeTypes()
-	// error is explicitly ingored here, because Azure returns
-	// apparently too big of a JSON for Go to parse
-	if len(list.NodeTypes) == 0 {
-		return defaultSmallestNodeType(a)
+// nodeTypeFilter is NodeTypeRequest with compiled regular expressions
+type nodeTypeFilter struct {
+	NodeTypeRequest
+	family        *regexp.Regexp
+	excludeFamily *regexp.Regexp
+}
+
+func newNodeTypeFilter(r NodeTypeRequest) (f nodeTypeFilter, err error) {
+	f.NodeTypeRequest = r
+	if r.FamilyRegex != "" {
+		if f.family, err = regexp.Compile(r.FamilyRegex); err != nil {
+			return f, fmt.Errorf("family_regex: %w", err)
+		}
+	}
+	if r.ExcludeFamilyRegex != "" {
+		if f.excludeFamily, err = regexp.Compile(r.ExcludeFamilyRegex); err != nil {
+			return f, fmt.Errorf("exclude_family_regex: %w", err)
+		}
+	}
+	return f, nil
+}
+
+// gravitonNodeType matches AWS Graviton families, like m6g, c6gd or r6gn
+var gravitonNodeType = regexp.MustCompile(`^[a-z]+\d+g[a-z]*\.`)
+
+// IsGravitonNodeType tells if node type runs on ARM processors
+func IsGravitonNodeType(nt NodeType) bool {
+	return nt.IsGraviton || gravitonNodeType.MatchString(nt.NodeTypeID)
+}
+
+// isFleetNodeType tells if node type is AWS fleet, like md-fleet.xlarge,
+// which picks the instance type with the best capacity from the family
+func isFleetNodeType(nt NodeType) bool {
+	return strings.Contains(nt.NodeTypeID, "-fleet.")
+}
+
+// isUnavailable tells if node type cannot be provisioned in the region or subscription
+func isUnavailable(nt NodeType) bool {
+	if nt.NodeInfo == nil {
+		return false
+	}
+	if len(nt.NodeInfo.Status) > 0 {
+		return true
+	}
+	return nt.NodeInfo.TotalCoreQuota > 0 && nt.NodeInfo.AvailableCoreQuota < nt.NumCores
+}
+
+func localDiskGB(nt NodeType) int32 {
+	if nt.NodeInstanceType == nil {
+		return 0
+	}
+	return nt.NodeInstanceType.LocalDisks*nt.NodeInstanceType.LocalDiskSizeGB +
+		nt.NodeInstanceType.LocalNVMeDisks*nt.NodeInstanceType.LocalNVMeDiskSizeGB
+}
+
+// Matches tells if node type fits all of the criteria
+func (f nodeTypeFilter) Matches(nt NodeType) bool {
+	gbs := (nt.MemoryMB / 1024)
+	if f.MinMemoryGB > 0 && gbs < f.MinMemoryGB {
+		return false
+	}
+	if f.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < f.GBPerCore {
+		return false
+	}
+	if f.MinCores > 0 && int32(nt.NumCores) < f.MinCores {
+		return false
+	}
+	if f.MinGPUs > 0 && nt.NumGPUs < f.MinGPUs {
+		return false
+	}
+	if f.LocalDisk && nt.NodeInstanceType != nil &&
+		(nt.NodeInstanceType.LocalDisks < 1 &&
+			nt.NodeInstanceType.LocalNVMeDisks < 1) {
+		return false
+	}
+	if f.MinLocalDiskGB > 0 && localDiskGB(nt) < f.MinLocalDiskGB {
+		return false
+	}
+	if f.Category != "" && !strings.EqualFold(nt.Category, f.Category) {
+		return false
+	}
+	if f.IsIOCacheEnabled && nt.IsIOCacheEnabled != f.IsIOCacheEnabled {
+		return false
+	}
+	if f.SupportPortForwarding && nt.SupportPortForwarding != f.SupportPortForwarding {
+		return false
+	}
+	if f.PhotonDriverCapable && nt.PhotonDriverCapable != f.PhotonDriverCapable {
+		return false
+	}
+	if f.PhotonWorkerCapable && nt.PhotonWorkerCapable != f.PhotonWorkerCapable {
+		return false
+	}
+	if f.family != nil && !f.family.MatchString(nt.NodeTypeID) {
+		return false
+	}
+	if f.excludeFamily != nil && f.excludeFamily.MatchString(nt.NodeTypeID) {
+		return false
+	}
+	if f.Graviton && !IsGravitonNodeType(nt) {
+		return false
+	}
+	if f.ExcludeDeprecated && nt.IsDeprecated {
+		return false
+	}
+	if f.ExcludeUnavailable && isUnavailable(nt) {
+		return false
+	}
+	return true
+}
+
+// ListNodeTypeCandidates returns node types, that match the criteria, from the smallest to
+// the largest. Fleet node types go first, if they are preferred.
+func (a ClustersAPI) ListNodeTypeCandidates(r NodeTypeRequest) ([]string, error) {
+	filter, err := newNodeTypeFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	list, err := a.ListNodeTypes()
+	if err != nil {
+		// error is explicitly ingored here, because Azure returns
+		// apparently too big of a JSON for Go to parse
+		log.Printf("[WARN] Cannot list node types: %v", err)
+		return []string{}, nil
 	}
 	list.Sort()
+	if r.Fleet {
+		sort.SliceStable(list.NodeTypes, func(i, j int) bool {
+			return isFleetNodeType(list.NodeTypes[i]) && !isFleetNodeType(list.NodeTypes[j])
+		})
+	}
+	candidates := []string{}
 	for _, nt := range list.NodeTypes {
-		gbs := (nt.MemoryMB / 1024)
-		if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
-			continue
-		}
-		if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
-			continue
-		}
-		if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
-			continue
-		}
-		if r.MinGPUs > 0 && nt.NumGPUs < r.MinGPUs {
-			continue
-		}
-		if r.LocalDisk && nt.NodeInstanceType != nil &&
-			(nt.NodeInstanceType.LocalDisks < 1 &&
-				nt.NodeInstanceType.LocalNVMeDisks < 1) {
-			continue
-		}
-		if r.Category != "" && !strings.EqualFold(nt.Category, r.Category) {
-			continue
-		}
-		if r.IsIOCacheEnabled && nt.IsIOCacheEnabled != r.IsIOCacheEnabled {
-			continue
+		if filter.Matches(nt) {
+			candidates = append(candidates, nt.NodeTypeID)
 		}
-		if r.SupportPortForwarding && nt.SupportPortForwarding != r.SupportPortForwarding {
-			continue
-		}
-		if r.PhotonDriverCapable && nt.PhotonDriverCapable != r.PhotonDriverCapable {
-			continue
-		}
-		if r.PhotonWorkerCapable && nt.PhotonWorkerCapable != r.PhotonWorkerCapable {
-			continue
-		}
-		return nt.NodeTypeID
 	}
-	return defaultSmallestNodeType(a)
+	return candidates, nil
+}
+
+// GetSmallestNodeType returns smallest (or default) node type id given the criteria
+func (a ClustersAPI) GetSmallestNodeType(r NodeTypeRequest) string {
+	candidates, err := a.ListNodeTypeCandidates(r)
+	if err != nil {
+		log.Printf("[WARN] Cannot find node type: %v", err)
+	}
+	if len(candidates) == 0 {
+		return defaultSmallestNodeType(a)
+	}
+	return candidates[0]
 }
 
 // DataSourceNodeType returns smallest node depedning on the cloud
 func DataSourceNodeType() *schema.Resource {
 	s := common.StructToSchema(NodeTypeRequest{}, func(
 		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["family_regex"].ValidateFunc = validation.StringIsValidRegExp
+		s["exclude_family_regex"].ValidateFunc = validation.StringIsValidRegExp
 		return s
 	})
 	return &schema.Resource{
@@ -178,7 +298,19 @@ func DataSourceNodeType() *schema.Resource {
 				return diag.FromErr(err)
 			}
 			clustersAPI := NewClustersAPI(ctx, m)
-			d.SetId(clustersAPI.GetSmallestNodeType(this))
+			candidates, err := clustersAPI.ListNodeTypeCandidates(this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(candidates) == 0 {
+				// keeps compatibility with the previous behavior
+				d.SetId(defaultSmallestNodeType(clustersAPI))
+			} else {
+				d.SetId(candidates[0])
+			}
+			if err = d.Set("candidates", candidates); err != nil {
+				return diag.FromErr(err)
+			}
 			return nil
 		},
 	}
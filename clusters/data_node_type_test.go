@@ -162,3 +162,138 @@ func TestNodeTypeCategory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Random_02", d.Id())
 }
+
+var awsNodeTypesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/clusters/list-node-types",
+	Response: NodeTypeList{
+		[]NodeType{
+			{
+				NodeTypeID: "m4.large",
+				MemoryMB:   8192,
+				NumCores:   2,
+			},
+			{
+				NodeTypeID:   "m4.xlarge",
+				MemoryMB:     16384,
+				NumCores:     4,
+				IsDeprecated: true,
+			},
+			{
+				NodeTypeID: "m5d.large",
+				MemoryMB:   8192,
+				NumCores:   2,
+				NodeInstanceType: &NodeInstanceType{
+					LocalNVMeDisks:      1,
+					LocalNVMeDiskSizeGB: 75,
+				},
+			},
+			{
+				NodeTypeID: "m5d.xlarge",
+				MemoryMB:   16384,
+				NumCores:   4,
+				NodeInstanceType: &NodeInstanceType{
+					LocalNVMeDisks:      1,
+					LocalNVMeDiskSizeGB: 150,
+				},
+			},
+			{
+				NodeTypeID: "m6gd.large",
+				MemoryMB:   8192,
+				NumCores:   2,
+				NodeInstanceType: &NodeInstanceType{
+					LocalNVMeDisks:      1,
+					LocalNVMeDiskSizeGB: 118,
+				},
+			},
+			{
+				NodeTypeID: "md-fleet.xlarge",
+				MemoryMB:   16384,
+				NumCores:   4,
+				NodeInstanceType: &NodeInstanceType{
+					LocalNVMeDisks:      1,
+					LocalNVMeDiskSizeGB: 150,
+				},
+			},
+			{
+				NodeTypeID: "i3.xlarge",
+				MemoryMB:   31232,
+				NumCores:   4,
+				NodeInfo: &ClusterCloudProviderNodeInfo{
+					Status: []string{"NotAvailableInRegion"},
+				},
+			},
+		},
+	},
+}
+
+func TestNodeTypeCandidates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{awsNodeTypesFixture},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL: `
+		family_regex = "^m"
+		exclude_family_regex = "^m6g"
+		min_local_disk_gb = 100
+		exclude_deprecated = true
+		fleet = true
+		`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "md-fleet.xlarge", d.Id())
+	assert.Equal(t, []interface{}{"md-fleet.xlarge", "m5d.xlarge"}, d.Get("candidates"))
+}
+
+func TestNodeTypeGraviton(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{awsNodeTypesFixture},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL:         `graviton = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "m6gd.large", d.Id())
+}
+
+func TestNodeTypeExcludeUnavailable(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{awsNodeTypesFixture},
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL: `
+		min_memory_gb = 30
+		exclude_unavailable = true
+		`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{}, d.Get("candidates"))
+	// falls back to the default node type
+	assert.Equal(t, "i3.xlarge", d.Id())
+}
+
+func TestNodeTypeInvalidRegex(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		HCL:         `family_regex = "^m("`,
+		ID:          ".",
+	}.ExpectError(t, "invalid config supplied. [family_regex] family_regex: "+
+		"error parsing regexp: missing closing ): `^m(`")
+}
+
+func TestIsGravitonNodeType(t *testing.T) {
+	assert.True(t, IsGravitonNodeType(NodeType{NodeTypeID: "c6gd.xlarge"}))
+	assert.True(t, IsGravitonNodeType(NodeType{NodeTypeID: "r6g.large"}))
+	assert.True(t, IsGravitonNodeType(NodeType{NodeTypeID: "x", IsGraviton: true}))
+	assert.False(t, IsGravitonNodeType(NodeType{NodeTypeID: "m5d.large"}))
+	assert.False(t, IsGravitonNodeType(NodeType{NodeTypeID: "Standard_F4s"}))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"golang.org/x/mod/semver"
 )
 
@@ -27,15 +28,21 @@ type SparkVersionsList struct {
 
 // SparkVersionRequest - filtering request
 type SparkVersionRequest struct {
-	LongTermSupport bool   `json:"long_term_support,omitempty" tf:"optional,default:false"`
-	Beta            bool   `json:"beta,omitempty" tf:"optional,default:false,conflicts:long_term_support"`
-	Latest          bool   `json:"latest,omitempty" tf:"optional,default:true"`
-	ML              bool   `json:"ml,omitempty" tf:"optional,default:false"`
-	Genomics        bool   `json:"genomics,omitempty" tf:"optional,default:false"`
-	GPU             bool   `json:"gpu,omitempty" tf:"optional,default:false"`
-	Scala           string `json:"scala,omitempty" tf:"optional,default:2.12"`
-	SparkVersion    string `json:"spark_version,omitempty" tf:"optional,default:"`
-	Photon          bool   `json:"photon,omitempty" tf:"optional,default:false"`
+	LongTermSupport   bool   `json:"long_term_support,omitempty" tf:"optional,default:false"`
+	Beta              bool   `json:"beta,omitempty" tf:"optional,default:false,conflicts:long_term_support"`
+	Latest            bool   `json:"latest,omitempty" tf:"optional,default:true"`
+	ML                bool   `json:"ml,omitempty" tf:"optional,default:false"`
+	Genomics          bool   `json:"genomics,omitempty" tf:"optional,default:false"`
+	GPU               bool   `json:"gpu,omitempty" tf:"optional,default:false"`
+	Scala             string `json:"scala,omitempty" tf:"optional,default:2.12"`
+	SparkVersion      string `json:"spark_version,omitempty" tf:"optional,default:"`
+	Photon            bool   `json:"photon,omitempty" tf:"optional,default:false"`
+	AArch64           bool   `json:"aarch64,omitempty" tf:"optional,default:false"`
+	MinVersion        string `json:"min_version,omitempty"`
+	MaxVersion        string `json:"max_version,omitempty"`
+	VersionConstraint string `json:"version_constraint,omitempty"`
+
+	Versions []string `json:"versions,omitempty" tf:"computed"`
 }
 
 // ListSparkVersions returns smallest (or default) node type id given the criteria
@@ -68,10 +75,132 @@ func (s sparkVersionsType) Less(i, j int) bool {
 	return semver.Compare("v"+extractDbrVersions(s[i]), "v"+extractDbrVersions(s[j])) > 0
 }
 
-// LatestSparkVersion returns latest version matching the request parameters
-func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionRequest) (string, error) {
-	var versions []string
+// dbrVersionConstraintRegex matches major (10) or major and minor (10.4) DBR version
+var dbrVersionConstraintRegex = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// truncateDbrVersion cuts the DBR version to the precision of the constraint,
+// so that 10.4 is within the maximum version of 10
+func truncateDbrVersion(version, constraint string) string {
+	if strings.Contains(constraint, ".") {
+		return semver.MajorMinor(version)
+	}
+	return semver.Major(version)
+}
+
+// dbrVersionCondition is a single condition of version constraint, like `>= 10.4`
+type dbrVersionCondition struct {
+	operator string
+	version  string
+}
+
+var dbrVersionConditionRegex = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~>)?\s*(\d+(?:\.\d+)?)$`)
+
+// parseDbrVersionConstraint parses comma-separated conditions, where operator is one of
+// =, !=, >, >=, <, <= or ~> and defaults to =
+func parseDbrVersionConstraint(constraint string) (conditions []dbrVersionCondition, err error) {
+	for _, part := range strings.Split(constraint, ",") {
+		m := dbrVersionConditionRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, fmt.Errorf("invalid version constraint: %s", strings.TrimSpace(part))
+		}
+		operator := m[1]
+		if operator == "" {
+			operator = "="
+		}
+		conditions = append(conditions, dbrVersionCondition{operator, m[2]})
+	}
+	return conditions, nil
+}
 
+// matches compares DBR version with the precision of condition, so that 10.4 equals to 10,
+// and ~> 10.4 allows later minor versions of 10, like ~> in Terraform
+func (c dbrVersionCondition) matches(v string) bool {
+	cmp := semver.Compare(truncateDbrVersion(v, c.version), "v"+c.version)
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return semver.Compare(v, "v"+c.version) < 0
+	case "<=":
+		return cmp <= 0
+	case "~>":
+		return semver.Compare(v, "v"+c.version) >= 0 &&
+			semver.Major(v) == semver.Major("v"+c.version)
+	}
+	return false
+}
+
+func validateDbrVersionConstraint(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := parseDbrVersionConstraint(v); err != nil {
+		return nil, []error{err}
+	}
+	return
+}
+
+// matchesDbrVersion tells if DBR version is within minimum and maximum versions, both inclusive,
+// and satisfies version constraint
+func (req SparkVersionRequest) matchesDbrVersion(version string) bool {
+	v := "v" + extractDbrVersions(version)
+	if !semver.IsValid(v) {
+		return req.MinVersion == "" && req.MaxVersion == "" && req.VersionConstraint == ""
+	}
+	if req.VersionConstraint != "" {
+		// constraint is validated before matching
+		conditions, _ := parseDbrVersionConstraint(req.VersionConstraint)
+		for _, c := range conditions {
+			if !c.matches(v) {
+				return false
+			}
+		}
+	}
+	if req.MinVersion != "" && semver.Compare(v, "v"+req.MinVersion) < 0 {
+		return false
+	}
+	if req.MaxVersion != "" &&
+		semver.Compare(truncateDbrVersion(v, req.MaxVersion), "v"+req.MaxVersion) > 0 {
+		return false
+	}
+	return true
+}
+
+func (req SparkVersionRequest) validate() error {
+	for name, constraint := range map[string]string{
+		"min_version": req.MinVersion,
+		"max_version": req.MaxVersion,
+	} {
+		if constraint != "" && !dbrVersionConstraintRegex.MatchString(constraint) {
+			return fmt.Errorf("%s must be like 10 or 10.4, but got %s", name, constraint)
+		}
+	}
+	if req.VersionConstraint != "" {
+		if _, err := parseDbrVersionConstraint(req.VersionConstraint); err != nil {
+			return err
+		}
+	}
+	if req.MinVersion != "" && req.MaxVersion != "" &&
+		semver.Compare("v"+req.MinVersion, "v"+req.MaxVersion) > 0 {
+		return fmt.Errorf("min_version %s is greater than max_version %s",
+			req.MinVersion, req.MaxVersion)
+	}
+	return nil
+}
+
+// MatchingSparkVersions returns all versions matching the request parameters, from the latest to the oldest
+func (sparkVersions SparkVersionsList) MatchingSparkVersions(req SparkVersionRequest) ([]string, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	versions := []string{}
 	for _, version := range sparkVersions.SparkVersions {
 		if strings.Contains(version.Version, "-scala"+req.Scala) {
 			matches := ((!strings.Contains(version.Version, "apache-spark-")) &&
@@ -79,7 +208,9 @@ func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionReques
 				(strings.Contains(version.Version, "-hls-") == req.Genomics) &&
 				(strings.Contains(version.Version, "-gpu-") == req.GPU) &&
 				(strings.Contains(version.Version, "-photon-") == req.Photon) &&
-				(strings.Contains(version.Description, "Beta") == req.Beta))
+				(strings.Contains(version.Version, "-aarch64-") == req.AArch64) &&
+				(strings.Contains(version.Description, "Beta") == req.Beta) &&
+				req.matchesDbrVersion(version.Version))
 			if matches && req.LongTermSupport {
 				matches = (matches && (strings.Contains(version.Description, "LTS") || strings.Contains(version.Version, "-esr-")))
 			}
@@ -91,16 +222,21 @@ func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionReques
 			}
 		}
 	}
+	sort.Stable(sparkVersionsType(versions))
+	return versions, nil
+}
+
+// LatestSparkVersion returns latest version matching the request parameters
+func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionRequest) (string, error) {
+	versions, err := sparkVersions.MatchingSparkVersions(req)
+	if err != nil {
+		return "", err
+	}
 	if len(versions) < 1 {
 		return "", fmt.Errorf("spark versions query returned no results. Please change your search criteria and try again")
-	} else if len(versions) > 1 {
-		if req.Latest {
-			sort.Sort(sparkVersionsType(versions))
-		} else {
-			return "", fmt.Errorf("spark versions query returned multiple results. Please change your search criteria and try again")
-		}
+	} else if len(versions) > 1 && !req.Latest {
+		return "", fmt.Errorf("spark versions query returned multiple results. Please change your search criteria and try again")
 	}
-
 	return versions[0], nil
 }
 
@@ -126,6 +262,11 @@ func (a ClustersAPI) LatestSparkVersionOrDefault(svr SparkVersionRequest) string
 func DataSourceSparkVersion() *schema.Resource {
 	s := common.StructToSchema(SparkVersionRequest{}, func(
 		s map[string]*schema.Schema) map[string]*schema.Schema {
+		for _, k := range []string{"min_version", "max_version"} {
+			s[k].ValidateFunc = validation.StringMatch(dbrVersionConstraintRegex,
+				"should be like 10 or 10.4")
+		}
+		s["version_constraint"].ValidateFunc = validateDbrVersionConstraint
 		return s
 	})
 
@@ -137,10 +278,21 @@ func DataSourceSparkVersion() *schema.Resource {
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			version, err := NewClustersAPI(ctx, m).LatestSparkVersion(this)
+			sparkVersions, err := NewClustersAPI(ctx, m).ListSparkVersions()
 			if err != nil {
 				return diag.FromErr(err)
 			}
+			version, err := sparkVersions.LatestSparkVersion(this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			versions, err := sparkVersions.MatchingSparkVersions(this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if err = d.Set("versions", versions); err != nil {
+				return diag.FromErr(err)
+			}
 			d.SetId(version)
 			return nil
 		},
@@ -234,3 +234,153 @@ func TestSparkVersionErrorBadAnswer(t *testing.T) {
 	assert.Error(t, err)
 	require.Equal(t, true, strings.Contains(err.Error(), "Invalid JSON received"))
 }
+
+func dbrVersionsFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: SparkVersionsList{
+				SparkVersions: []SparkVersion{
+					{
+						Version:     "9.1.x-gpu-ml-scala2.12",
+						Description: "9.1 LTS ML (includes Apache Spark 3.1.2, GPU, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-gpu-ml-scala2.12",
+						Description: "10.4 LTS ML (includes Apache Spark 3.2.1, GPU, Scala 2.12)",
+					},
+					{
+						Version:     "10.5.x-gpu-ml-scala2.12",
+						Description: "10.5 ML (includes Apache Spark 3.2.1, GPU, Scala 2.12)",
+					},
+					{
+						Version:     "11.3.x-gpu-ml-scala2.12",
+						Description: "11.3 LTS ML (includes Apache Spark 3.3.0, GPU, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-scala2.12",
+						Description: "10.4 LTS (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-aarch64-scala2.12",
+						Description: "10.4 LTS aarch64 (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "11.3.x-aarch64-scala2.12",
+						Description: "11.3 LTS aarch64 (includes Apache Spark 3.3.0, Scala 2.12)",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSparkVersionMaxVersionMajor(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    dbrVersionsFixtures(),
+		Read:        true,
+		Resource:    DataSourceSparkVersion(),
+		NonWritable: true,
+		HCL: `
+		long_term_support = true
+		ml = true
+		gpu = true
+		max_version = "10"
+		`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.4.x-gpu-ml-scala2.12", d.Id())
+	assert.Equal(t, []interface{}{"10.4.x-gpu-ml-scala2.12", "9.1.x-gpu-ml-scala2.12"},
+		d.Get("versions"))
+}
+
+func TestSparkVersionMinMaxVersion(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    dbrVersionsFixtures(),
+		Read:        true,
+		Resource:    DataSourceSparkVersion(),
+		NonWritable: true,
+		HCL: `
+		ml = true
+		gpu = true
+		min_version = "10.4"
+		max_version = "11.0"
+		`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.5.x-gpu-ml-scala2.12", d.Id())
+	assert.Equal(t, []interface{}{"10.5.x-gpu-ml-scala2.12", "10.4.x-gpu-ml-scala2.12"},
+		d.Get("versions"))
+}
+
+func TestSparkVersionAArch64(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    dbrVersionsFixtures(),
+		Read:        true,
+		Resource:    DataSourceSparkVersion(),
+		NonWritable: true,
+		HCL:         `aarch64 = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "11.3.x-aarch64-scala2.12", d.Id())
+	assert.Equal(t, []interface{}{"11.3.x-aarch64-scala2.12", "10.4.x-aarch64-scala2.12"},
+		d.Get("versions"))
+}
+
+func TestSparkVersionInvalidConstraint(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceSparkVersion(),
+		NonWritable: true,
+		HCL:         `min_version = ">= 10"`,
+		ID:          ".",
+	}.ExpectError(t, "invalid config supplied. [min_version] invalid value for min_version (should be like 10 or 10.4)")
+}
+
+func TestSparkVersionMinGreaterThanMax(t *testing.T) {
+	_, err := SparkVersionsList{}.LatestSparkVersion(SparkVersionRequest{
+		MinVersion: "11",
+		MaxVersion: "10.4",
+	})
+	assert.EqualError(t, err, "min_version 11 is greater than max_version 10.4")
+}
+
+func TestSparkVersionConstraint(t *testing.T) {
+	for constraint, expected := range map[string][]interface{}{
+		">= 10, < 11":   {"10.5.x-gpu-ml-scala2.12", "10.4.x-gpu-ml-scala2.12"},
+		"~> 10.4":       {"10.5.x-gpu-ml-scala2.12", "10.4.x-gpu-ml-scala2.12"},
+		"10, != 10.5":   {"10.4.x-gpu-ml-scala2.12"},
+		"> 10":          {"11.3.x-gpu-ml-scala2.12"},
+		"<= 10.4":       {"10.4.x-gpu-ml-scala2.12", "9.1.x-gpu-ml-scala2.12"},
+		"> 9.1, < 10.5": {"10.4.x-gpu-ml-scala2.12"},
+	} {
+		d, err := qa.ResourceFixture{
+			Fixtures:    dbrVersionsFixtures(),
+			Read:        true,
+			Resource:    DataSourceSparkVersion(),
+			NonWritable: true,
+			HCL: `
+			ml = true
+			gpu = true
+			version_constraint = "` + constraint + `"
+			`,
+			ID: ".",
+		}.Apply(t)
+		require.NoError(t, err, constraint)
+		assert.Equal(t, expected, d.Get("versions"), constraint)
+	}
+}
+
+func TestSparkVersionInvalidVersionConstraint(t *testing.T) {
+	qa.ResourceFixture{
+		Read:        true,
+		Resource:    DataSourceSparkVersion(),
+		NonWritable: true,
+		HCL:         `version_constraint = ">= 10, =< 11"`,
+		ID:          ".",
+	}.ExpectError(t, "invalid config supplied. [version_constraint] invalid version constraint: =< 11")
+}
@@ -27,6 +27,21 @@ func ResourceCluster() *schema.Resource {
 			d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewClustersAPI(ctx, c).PermanentDelete(d.Id())
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			var cluster Cluster
+			if err := common.DiffToStructPointer(d, clusterSchema, &cluster); err != nil {
+				return err
+			}
+			if err := ValidateClusterPolicy(ctx, d, c, cluster, "", "all-purpose"); err != nil {
+				return err
+			}
+			if d.Id() != "" && isRestartPlanned(d) {
+				// SDK doesn't allow warnings in plan, so restart is shown as unknown state
+				log.Printf("[WARN] %s is running and will be restarted to apply changes", d.Id())
+				return d.SetNewComputed("state")
+			}
+			return nil
+		},
 		Schema:        clusterSchema,
 		SchemaVersion: 2,
 		Timeouts: &schema.ResourceTimeout{
@@ -93,6 +108,21 @@ func resourceClusterSchema() map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		}
+		s["desired_state"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			// nolint once SDKv2 has Diagnostics-returning validators, change
+			ValidateFunc: validation.StringInSlice([]string{
+				ClusterStateRunning, ClusterStateTerminated,
+			}, false),
+		}
+		s["update_strategy"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				UpdateStrategyRestart, UpdateStrategyWaitForIdle, UpdateStrategyOnNextTermination,
+			}, false),
+		}
 		s["default_tags"] = &schema.Schema{
 			Type:     schema.TypeMap,
 			Computed: true,
@@ -151,6 +181,10 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 			return err
 		}
 	}
+	if d.Get("desired_state").(string) == ClusterStateTerminated {
+		log.Printf("[INFO] %s is created with desired TERMINATED state", d.Id())
+		return clusters.Terminate(d.Id())
+	}
 	return nil
 }
 
@@ -172,6 +206,16 @@ func setPinnedStatus(d *schema.ResourceData, clusterAPI ClustersAPI) error {
 	return d.Set("is_pinned", pinnedEvent == EvTypePinned)
 }
 
+// actualDesiredState maps cluster state to the one of desired_state values
+func actualDesiredState(clusterInfo ClusterInfo) string {
+	switch clusterInfo.State {
+	case ClusterStatePending, ClusterStateRunning, ClusterStateRestarting, ClusterStateResizing:
+		return ClusterStateRunning
+	default:
+		return ClusterStateTerminated
+	}
+}
+
 func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	clusterAPI := NewClustersAPI(ctx, c)
 	clusterInfo, err := clusterAPI.Get(d.Id())
@@ -181,11 +225,24 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err = common.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
+	if d.Get("desired_state").(string) != "" {
+		// cluster started or terminated outside of Terraform shows up as a drift
+		d.Set("desired_state", actualDesiredState(clusterInfo))
+	}
 	if err = setPinnedStatus(d, clusterAPI); err != nil {
 		return err
 	}
 	d.Set("url", c.FormatURL("#setting/clusters/", d.Id(), "/configuration"))
 	librariesAPI := libraries.NewLibrariesAPI(ctx, c)
+	if !clusterInfo.IsRunningOrResizing() {
+		// libraries of terminated cluster are installed only on the next start,
+		// so there's nothing to wait for
+		libsClusterStatus, err := librariesAPI.ClusterStatus(d.Id())
+		if err != nil {
+			return err
+		}
+		return common.StructToData(libsClusterStatus.ToLibraryList(), clusterSchema, d)
+	}
 	libsClusterStatus, err := librariesAPI.WaitForLibrariesInstalled(d.Id(), d.Timeout(schema.TimeoutRead))
 	if err != nil {
 		return err
@@ -194,10 +251,21 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	return common.StructToData(libList, clusterSchema, d)
 }
 
-func hasClusterConfigChanged(d *schema.ResourceData) bool {
+// nonClusterConfigKeys are changed without editing the cluster
+var nonClusterConfigKeys = map[string]bool{
+	"library":         true,
+	"is_pinned":       true,
+	"desired_state":   true,
+	"update_strategy": true,
+}
+
+type changeTracker interface {
+	HasChange(key string) bool
+}
+
+func hasClusterConfigChanged(d changeTracker) bool {
 	for k := range clusterSchema {
-		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" {
+		if nonClusterConfigKeys[k] {
 			continue
 		}
 		if d.HasChange(k) {
@@ -207,6 +275,18 @@ func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	return false
 }
 
+// isRestartPlanned checks if running cluster is going to be restarted on apply
+func isRestartPlanned(d *schema.ResourceDiff) bool {
+	state := d.Get("state").(string)
+	if state != ClusterStateRunning && state != ClusterStateResizing {
+		return false
+	}
+	if d.Get("update_strategy").(string) == UpdateStrategyOnNextTermination {
+		return false
+	}
+	return hasClusterConfigChanged(d)
+}
+
 // https://github.com/databrickslabs/terraform-provider-databricks/issues/824
 func fixInstancePoolChangeIfAny(d *schema.ResourceData, cluster *Cluster) {
 	oldInstancePool, newInstancePool := d.GetChange("instance_pool_id")
@@ -227,6 +307,7 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		return err
 	}
 	var clusterInfo ClusterInfo
+	deferred, restarted := false, false
 	if hasClusterConfigChanged(d) {
 		log.Printf("[DEBUG] Cluster state has changed!")
 		if err = cluster.Validate(); err != nil {
@@ -234,7 +315,18 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 		}
 		cluster.ModifyRequestOnInstancePool()
 		fixInstancePoolChangeIfAny(d, &cluster)
-		clusterInfo, err = clusters.Edit(cluster)
+		deferred, err = applyUpdateStrategy(clusters, clusterID, d.Get("update_strategy").(string),
+			d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+		if deferred {
+			clusterInfo, err = clusters.Get(clusterID)
+		} else {
+			clusterInfo, err = clusters.Edit(cluster)
+			// edit of running cluster restarts it
+			restarted = clusterInfo.IsRunningOrResizing()
+		}
 		if err != nil {
 			return err
 		}
@@ -266,23 +358,76 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err != nil {
 		return err
 	}
+	desiredState := d.Get("desired_state").(string)
+	if desiredState == ClusterStateRunning && !clusterInfo.IsRunningOrResizing() {
+		log.Printf("[INFO] Starting %s, as desired state is RUNNING", clusterID)
+		if clusterInfo, err = clusters.StartAndGetInfo(clusterID); err != nil {
+			return err
+		}
+	}
 	libraryList.ClusterID = clusterID
 	libsToInstall, libsToUninstall := libraryList.Diff(libsClusterStatus)
 	if len(libsToUninstall.Libraries) > 0 || len(libsToInstall.Libraries) > 0 {
-		if !clusterInfo.IsRunningOrResizing() {
-			if _, err = clusters.StartAndGetInfo(clusterID); err != nil {
-				return err
-			}
+		if clusterInfo.IsRunningOrResizing() {
+			err = librariesAPI.UpdateLibraries(clusterID, libsToInstall, libsToUninstall)
+		} else {
+			// there's no need to start the cluster, as libraries are
+			// installed or removed on the next start
+			err = updateLibrariesOfTerminatedCluster(librariesAPI, libsToInstall, libsToUninstall)
 		}
-		if err = librariesAPI.UpdateLibraries(clusterID, libsToInstall, libsToUninstall); err != nil {
+		if err != nil {
 			return err
 		}
-		if clusterInfo.State == ClusterStateTerminated {
-			log.Printf("[INFO] %s was in TERMINATED state, so terminating it again", clusterID)
-			if err = clusters.Terminate(clusterID); err != nil {
-				return err
-			}
+	}
+	if desiredState == ClusterStateTerminated && clusterInfo.State != ClusterStateTerminated {
+		log.Printf("[INFO] Terminating %s, as desired state is TERMINATED", clusterID)
+		if err = clusters.Terminate(clusterID); err != nil {
+			return err
+		}
+	}
+	if deferred {
+		return common.Warningf("%s is running, so configuration changes are deferred till it's "+
+			"terminated and will show up in the next plan", clusterID)
+	}
+	if restarted {
+		return common.Warningf("%s was restarted to apply configuration changes", clusterID)
+	}
+	return nil
+}
+
+// applyUpdateStrategy waits until running cluster could be restarted or tells if the edit
+// has to be deferred till the cluster is terminated
+func applyUpdateStrategy(clusters ClustersAPI, clusterID, strategy string,
+	timeout time.Duration) (deferred bool, err error) {
+	if strategy == "" || strategy == UpdateStrategyRestart {
+		return false, nil
+	}
+	clusterInfo, err := clusters.Get(clusterID)
+	if err != nil {
+		return false, err
+	}
+	if !clusterInfo.IsRunningOrResizing() {
+		return false, nil
+	}
+	if strategy == UpdateStrategyOnNextTermination {
+		// running cluster keeps the old configuration, so the change
+		// shows up in the next plan and is applied once it's terminated
+		log.Printf("[WARN] %s is %s, so changes are deferred till it's terminated",
+			clusterID, clusterInfo.State)
+		return true, nil
+	}
+	return false, clusters.waitForIdle(clusterID, timeout)
+}
+
+func updateLibrariesOfTerminatedCluster(librariesAPI libraries.LibrariesAPI,
+	libsToInstall, libsToUninstall libraries.ClusterLibraryList) error {
+	if len(libsToUninstall.Libraries) > 0 {
+		if err := librariesAPI.Uninstall(libsToUninstall); err != nil {
+			return err
 		}
 	}
+	if len(libsToInstall.Libraries) > 0 {
+		return librariesAPI.Install(libsToInstall)
+	}
 	return nil
 }
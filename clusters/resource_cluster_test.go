@@ -1,14 +1,18 @@
 package clusters
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
 
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -456,6 +460,67 @@ func TestResourceClusterRead(t *testing.T) {
 	}
 }
 
+func TestResourceClusterRead_DesiredStateDrift(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 15,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		ID:       "abc",
+		State: map[string]interface{}{
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "i3.xlarge",
+			"num_workers":   100,
+			"desired_state": "TERMINATED",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+	// cluster was started outside of Terraform, so the next plan terminates it
+	assert.Equal(t, "RUNNING", d.Get("desired_state"))
+	assert.Equal(t, "RUNNING", d.Get("state"))
+}
+
+func TestActualDesiredState(t *testing.T) {
+	for state, expected := range map[string]string{
+		ClusterStatePending:     ClusterStateRunning,
+		ClusterStateRunning:     ClusterStateRunning,
+		ClusterStateRestarting:  ClusterStateRunning,
+		ClusterStateResizing:    ClusterStateRunning,
+		ClusterStateTerminating: ClusterStateTerminated,
+		ClusterStateTerminated:  ClusterStateTerminated,
+		ClusterStateError:       ClusterStateTerminated,
+	} {
+		assert.Equal(t, expected, actualDesiredState(ClusterInfo{State: ClusterState(state)}), state)
+	}
+}
+
 func TestResourceClusterRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -694,7 +759,7 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 	}
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
-			terminated, // 1 of ...
+			terminated, // 1 of 2
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/edit",
@@ -729,17 +794,6 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 					},
 				},
 			},
-			{ // check to see if cluster is restarting (if so wait)
-				Method:   "GET",
-				Resource: "/api/2.0/clusters/get?cluster_id=abc",
-				Response: ClusterInfo{
-					ClusterID:    "abc",
-					NumWorkers:   100,
-					SparkVersion: "7.1-scala12",
-					NodeTypeID:   "i3.xlarge",
-					State:        ClusterStateTerminated,
-				},
-			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/clusters/events",
@@ -754,25 +808,7 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 					TotalCount: 0,
 				},
 			},
-			{ // start cluster before libs install
-				Method:   "POST",
-				Resource: "/api/2.0/clusters/start",
-				ExpectedRequest: ClusterID{
-					ClusterID: "abc",
-				},
-			},
-			{ // 2 of ...
-				Method:   "GET",
-				Resource: "/api/2.0/clusters/get?cluster_id=abc",
-				Response: ClusterInfo{
-					ClusterID:    "abc",
-					NumWorkers:   100,
-					SparkVersion: "7.1-scala12",
-					NodeTypeID:   "i3.xlarge",
-					State:        ClusterStateRunning,
-				},
-			},
-			{
+			{ // libraries are installed on the next start
 				Method:   "POST",
 				Resource: "/api/2.0/libraries/uninstall",
 				ExpectedRequest: libraries.ClusterLibraryList{
@@ -798,17 +834,8 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 					},
 				},
 			},
-			newLibs,
-			{
-				Method:   "POST",
-				Resource: "/api/2.0/clusters/delete",
-				ExpectedRequest: ClusterID{
-					ClusterID: "abc",
-				},
-			},
-			terminated, // 3 of 4
 			// read
-			terminated, // 4 of 4
+			terminated, // 2 of 2
 			newLibs,
 		},
 		ID:       "abc",
@@ -830,6 +857,247 @@ func TestResourceClusterUpdate_LibrariesChangeOnTerminatedCluster(t *testing.T)
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
 }
 
+func TestResourceClusterCreate_DesiredStateTerminated(t *testing.T) {
+	running := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=abc",
+		Response: ClusterInfo{
+			ClusterID:    "abc",
+			NumWorkers:   100,
+			SparkVersion: "7.1-scala12",
+			NodeTypeID:   "i3.xlarge",
+			State:        ClusterStateRunning,
+		},
+	}
+	terminated := qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=abc",
+		Response: ClusterInfo{
+			ClusterID:    "abc",
+			NumWorkers:   100,
+			SparkVersion: "7.1-scala12",
+			NodeTypeID:   "i3.xlarge",
+			State:        ClusterStateTerminated,
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             100,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			running,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/delete",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			terminated,
+			// read
+			terminated,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Limit:      1,
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypePinned, EvTypeUnpinned},
+				},
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		desired_state = "TERMINATED"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "TERMINATED", d.Get("state"))
+	assert.Equal(t, "TERMINATED", d.Get("desired_state"))
+}
+
+func TestResourceClusterUpdate_DesiredState(t *testing.T) {
+	clusterInfo := func(state ClusterState) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:              "abc",
+				NumWorkers:             100,
+				SparkVersion:           "7.1-scala12",
+				NodeTypeID:             "i3.xlarge",
+				AutoterminationMinutes: 60,
+				State:                  state,
+			},
+		}
+	}
+	readFixtures := []qa.HTTPFixture{
+		{
+			Method:       "POST",
+			Resource:     "/api/2.0/clusters/events",
+			ReuseRequest: true,
+			Response: EventsResponse{
+				Events:     []ClusterEvent{},
+				TotalCount: 0,
+			},
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			ReuseRequest: true,
+			Response: libraries.ClusterLibraryStatuses{
+				LibraryStatuses: []libraries.LibraryStatus{},
+			},
+		},
+	}
+	state := map[string]string{
+		"autotermination_minutes": "60",
+		"spark_version":           "7.1-scala12",
+		"node_type_id":            "i3.xlarge",
+		"num_workers":             "100",
+	}
+	hcl := `num_workers = 100
+	spark_version = "7.1-scala12"
+	node_type_id = "i3.xlarge"
+	desired_state = "%s"`
+
+	d, err := qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			clusterInfo(ClusterStateTerminated),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/start",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			clusterInfo(ClusterStateRunning),
+			// read
+			clusterInfo(ClusterStateRunning),
+		}, readFixtures...),
+		ID:            "abc",
+		Update:        true,
+		Resource:      ResourceCluster(),
+		InstanceState: state,
+		HCL:           fmt.Sprintf(hcl, "RUNNING"),
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "RUNNING", d.Get("state"))
+
+	d, err = qa.ResourceFixture{
+		Fixtures: append([]qa.HTTPFixture{
+			clusterInfo(ClusterStateRunning),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/delete",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			clusterInfo(ClusterStateTerminated),
+			// read
+			clusterInfo(ClusterStateTerminated),
+		}, readFixtures...),
+		ID:            "abc",
+		Update:        true,
+		Resource:      ResourceCluster(),
+		InstanceState: state,
+		HCL:           fmt.Sprintf(hcl, "TERMINATED"),
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "TERMINATED", d.Get("state"))
+}
+
+func TestResourceClusterUpdate_EditOfTerminatedClusterDoesNotStartIt(t *testing.T) {
+	terminated := qa.HTTPFixture{
+		Method:       "GET",
+		Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+		ReuseRequest: true,
+		Response: ClusterInfo{
+			ClusterID:              "abc",
+			NumWorkers:             100,
+			SparkVersion:           "7.1-scala12",
+			NodeTypeID:             "i3.xlarge",
+			AutoterminationMinutes: 60,
+			State:                  ClusterStateTerminated,
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			terminated,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/edit",
+				ExpectedRequest: Cluster{
+					AutoterminationMinutes: 60,
+					ClusterID:              "abc",
+					NumWorkers:             200,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: libraries.ClusterLibraryStatuses{
+					LibraryStatuses: []libraries.LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events:     []ClusterEvent{},
+					TotalCount: 0,
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes": "60",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+			"desired_state":           "TERMINATED",
+		},
+		HCL: `num_workers = 200
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		desired_state = "TERMINATED"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "TERMINATED", d.Get("state"))
+}
+
 func TestResourceClusterUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -1107,3 +1375,252 @@ func TestModifyClusterRequestGcp(t *testing.T) {
 	assert.Equal(t, "", c.DriverNodeTypeID)
 	assert.Equal(t, false, c.EnableElasticDisk)
 }
+
+var runningClusterFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+	ReuseRequest: true,
+	Response: ClusterInfo{
+		ClusterID:              "abc",
+		NumWorkers:             100,
+		SparkVersion:           "7.1-scala12",
+		NodeTypeID:             "i3.xlarge",
+		AutoterminationMinutes: 60,
+		State:                  ClusterStateRunning,
+		LastActivityTime:       1,
+	},
+}
+
+var noLibrariesFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+	ReuseRequest: true,
+	Response: libraries.ClusterLibraryStatuses{
+		LibraryStatuses: []libraries.LibraryStatus{},
+	},
+}
+
+var noEventsFixture = qa.HTTPFixture{
+	Method:       "POST",
+	Resource:     "/api/2.0/clusters/events",
+	ReuseRequest: true,
+	Response: EventsResponse{
+		Events:     []ClusterEvent{},
+		TotalCount: 0,
+	},
+}
+
+func TestResourceClusterUpdate_OnNextTermination(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			runningClusterFixture,
+			noLibrariesFixture,
+			noEventsFixture,
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes": "60",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+			"state":                   "RUNNING",
+		},
+		HCL: `num_workers = 200
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		update_strategy = "on_next_termination"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	// change is planned again, until cluster is terminated
+	assert.Equal(t, 100, d.Get("num_workers"))
+}
+
+func TestResourceClusterUpdate_OnNextTerminationWarning(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		runningClusterFixture,
+		noLibrariesFixture,
+		noEventsFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		r := ResourceCluster()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"spark_version":   "7.1-scala12",
+			"node_type_id":    "i3.xlarge",
+			"num_workers":     200,
+			"update_strategy": "on_next_termination",
+		})
+		d.SetId("abc")
+		diags := r.UpdateContext(ctx, d, client)
+		assert.False(t, diags.HasError())
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "abc is running, so configuration changes are deferred till it's "+
+			"terminated and will show up in the next plan", diags[0].Summary)
+		assert.Equal(t, 100, d.Get("num_workers"))
+	})
+}
+
+func TestResourceClusterUpdate_RestartWarning(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		runningClusterFixture,
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/edit",
+			ExpectedRequest: Cluster{
+				ClusterID:              "abc",
+				NumWorkers:             200,
+				SparkVersion:           "7.1-scala12",
+				NodeTypeID:             "i3.xlarge",
+				AutoterminationMinutes: 60,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/start",
+			ExpectedRequest: ClusterID{
+				ClusterID: "abc",
+			},
+		},
+		noLibrariesFixture,
+		noEventsFixture,
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		r := ResourceCluster()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "i3.xlarge",
+			"num_workers":   200,
+		})
+		d.SetId("abc")
+		diags := r.UpdateContext(ctx, d, client)
+		assert.False(t, diags.HasError(), diags)
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "abc was restarted to apply configuration changes", diags[0].Summary)
+	})
+}
+
+func TestResourceClusterUpdate_WaitForIdle(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			runningClusterFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&limit=25",
+				Response: activeRunsList{
+					Runs: []activeRun{
+						{
+							RunID: 1,
+							ClusterInstance: clusterInstance{
+								ClusterID: "abc",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&limit=25",
+				Response: activeRunsList{
+					Runs: []activeRun{
+						{
+							RunID: 2,
+							ClusterInstance: clusterInstance{
+								ClusterID: "bcd",
+							},
+						},
+					},
+					HasMore: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&limit=25&offset=1",
+				Response: activeRunsList{},
+			},
+			noEventsFixture,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/edit",
+				ExpectedRequest: Cluster{
+					AutoterminationMinutes: 60,
+					ClusterID:              "abc",
+					NumWorkers:             200,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+				},
+			},
+			noLibrariesFixture,
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes": "60",
+			"spark_version":           "7.1-scala12",
+			"node_type_id":            "i3.xlarge",
+			"num_workers":             "100",
+			"state":                   "RUNNING",
+		},
+		HCL: `num_workers = 200
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		update_strategy = "wait_for_idle"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "RUNNING", d.Get("state"))
+}
+
+func TestClusterBusyReason(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/jobs/runs/list?active_only=true&limit=25",
+			ReuseRequest: true,
+			Response:     activeRunsList{},
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			ReuseRequest: true,
+			Response: ClusterInfo{
+				ClusterID:        "abc",
+				State:            ClusterStateRunning,
+				LastActivityTime: time.Now().UnixNano() / int64(time.Millisecond),
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		reason, err := NewClustersAPI(ctx, client).busyReason("abc")
+		require.NoError(t, err)
+		assert.Equal(t, "there was activity within last 5m0s", reason)
+	})
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/jobs/runs/list?active_only=true&limit=25",
+			ReuseRequest: true,
+			Response:     activeRunsList{},
+		},
+		runningClusterFixture,
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			Response: EventsResponse{
+				Events: []ClusterEvent{
+					{
+						Type: EvTypeResizing,
+						Details: EventDetails{
+							CurrentNumWorkers: 2,
+							TargetNumWorkers:  8,
+						},
+					},
+				},
+				TotalCount: 1,
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		reason, err := NewClustersAPI(ctx, client).busyReason("abc")
+		require.NoError(t, err)
+		assert.Equal(t, "cluster is upsizing to 8 workers", reason)
+	})
+}
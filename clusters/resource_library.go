@@ -0,0 +1,183 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceLibrary manages single library on a cluster, that could be defined
+// outside of the current configuration
+func ResourceLibrary() *schema.Resource {
+	s := common.StructToSchema(libraries.Library{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		libraryTypes := []string{"jar", "egg", "whl", "pypi", "maven", "cran"}
+		for _, k := range libraryTypes {
+			s[k].ExactlyOneOf = libraryTypes
+		}
+		s["cluster_id"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		}
+		return s
+	})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var lib libraries.Library
+			if err := common.DataToStructPointer(d, s, &lib); err != nil {
+				return err
+			}
+			clusterID := d.Get("cluster_id").(string)
+			clustersAPI := NewClustersAPI(ctx, c)
+			clusterInfo, err := clustersAPI.Get(clusterID)
+			if err != nil {
+				return err
+			}
+			librariesAPI := libraries.NewLibrariesAPI(ctx, c)
+			if clusterInfo.IsRunningOrResizing() {
+				cls, err := librariesAPI.ClusterStatus(clusterID)
+				if err != nil {
+					return err
+				}
+				status := findLibraryStatus(cls, lib.String())
+				if status != nil && status.Status == "UNINSTALL_ON_RESTART" {
+					// the same library was removed from running cluster, but it's still
+					// loaded, so it has to be restarted before installing it again
+					log.Printf("[INFO] Restarting %s to replace %s", clusterID, lib)
+					if err = clustersAPI.Restart(clusterID); err != nil {
+						return err
+					}
+					if _, err = clustersAPI.waitForClusterStatus(clusterID, ClusterStateRunning); err != nil {
+						return err
+					}
+				}
+			}
+			err = librariesAPI.Install(libraries.ClusterLibraryList{
+				ClusterID: clusterID,
+				Libraries: []libraries.Library{lib},
+			})
+			if err != nil {
+				return err
+			}
+			d.SetId(fmt.Sprintf("%s/%s", clusterID, lib))
+			if !clusterInfo.IsRunningOrResizing() {
+				log.Printf("[INFO] %s is %s, so %s is going to be installed on the next start",
+					clusterID, clusterInfo.State, lib)
+				return nil
+			}
+			_, err = waitForLibraryInstalled(librariesAPI, clusterID, lib.String(),
+				d.Timeout(schema.TimeoutCreate))
+			return err
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			clusterID, libraryRep, err := parseLibraryID(d.Id())
+			if err != nil {
+				return err
+			}
+			// library of removed cluster is removed as well
+			if _, err = NewClustersAPI(ctx, c).Get(clusterID); err != nil {
+				return err
+			}
+			// refresh doesn't wait for installation, as it's done during create
+			cls, err := libraries.NewLibrariesAPI(ctx, c).ClusterStatus(clusterID)
+			if err != nil {
+				return err
+			}
+			status := findLibraryStatus(cls, libraryRep)
+			if status != nil && status.Status == "FAILED" {
+				// failed library is planned to be installed again
+				log.Printf("[WARN] %s failed on %s: %s", libraryRep, clusterID,
+					strings.Join(status.Messages, ", "))
+				status = nil
+			}
+			if status == nil || status.Status == "UNINSTALL_ON_RESTART" {
+				return common.NotFound(fmt.Sprintf("cannot find %s on %s", libraryRep, clusterID))
+			}
+			if err = common.StructToData(*status.Library, s, d); err != nil {
+				return err
+			}
+			return d.Set("cluster_id", clusterID)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			clusterID, libraryRep, err := parseLibraryID(d.Id())
+			if err != nil {
+				return err
+			}
+			librariesAPI := libraries.NewLibrariesAPI(ctx, c)
+			cls, err := librariesAPI.ClusterStatus(clusterID)
+			if common.IsMissing(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			status := findLibraryStatus(cls, libraryRep)
+			if status == nil {
+				return nil
+			}
+			// library is removed on the next restart of the cluster,
+			// so that running workloads are not interrupted
+			return librariesAPI.Uninstall(libraries.ClusterLibraryList{
+				ClusterID: clusterID,
+				Libraries: []libraries.Library{*status.Library},
+			})
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DefaultProvisionTimeout),
+		},
+	}.ToResource()
+}
+
+func parseLibraryID(id string) (string, string, error) {
+	split := strings.SplitN(id, "/", 2)
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("invalid library ID: %s", id)
+	}
+	return split[0], split[1], nil
+}
+
+func findLibraryStatus(cls libraries.ClusterLibraryStatuses, libraryRep string) *libraries.LibraryStatus {
+	for _, status := range cls.LibraryStatuses {
+		if status.Library != nil && status.Library.String() == libraryRep {
+			found := status
+			return &found
+		}
+	}
+	return nil
+}
+
+// waitForLibraryInstalled waits only for a single library, so that pending or
+// failed libraries, that are managed elsewhere, do not interfere
+func waitForLibraryInstalled(librariesAPI libraries.LibrariesAPI, clusterID, libraryRep string,
+	timeout time.Duration) (status *libraries.LibraryStatus, err error) {
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		cls, err := librariesAPI.ClusterStatus(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		status = findLibraryStatus(cls, libraryRep)
+		if status == nil {
+			return nil
+		}
+		retry, err := libraries.ClusterLibraryStatuses{
+			ClusterID:       clusterID,
+			LibraryStatuses: []libraries.LibraryStatus{*status},
+		}.IsRetryNeeded()
+		if retry {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,242 @@
+package clusters
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func clusterInStateFixture(state ClusterState) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/clusters/get?cluster_id=abc",
+		Response: ClusterInfo{
+			ClusterID: "abc",
+			State:     state,
+		},
+	}
+}
+
+func libraryStatusFixture(statuses ...libraries.LibraryStatus) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:   "GET",
+		Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+		Response: libraries.ClusterLibraryStatuses{
+			ClusterID:       "abc",
+			LibraryStatuses: statuses,
+		},
+	}
+}
+
+var installFooJar = qa.HTTPFixture{
+	Method:   "POST",
+	Resource: "/api/2.0/libraries/install",
+	ExpectedRequest: libraries.ClusterLibraryList{
+		ClusterID: "abc",
+		Libraries: []libraries.Library{
+			{
+				Jar: "dbfs:/foo.jar",
+			},
+		},
+	},
+}
+
+func fooJarStatus(status string, messages ...string) libraries.LibraryStatus {
+	return libraries.LibraryStatus{
+		Library: &libraries.Library{
+			Jar: "dbfs:/foo.jar",
+		},
+		Status:   status,
+		Messages: messages,
+	}
+}
+
+func TestLibraryCreate_RunningCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(),
+			installFooJar,
+			libraryStatusFixture(fooJarStatus("INSTALLING")),
+			libraryStatusFixture(fooJarStatus("INSTALLED")),
+			// read
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("INSTALLED")),
+		},
+		Resource: ResourceLibrary(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		jar = "dbfs:/foo.jar"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/jar:dbfs:/foo.jar", d.Id())
+	assert.Equal(t, "dbfs:/foo.jar", d.Get("jar"))
+}
+
+func TestLibraryCreate_TerminatedCluster(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateTerminated),
+			installFooJar,
+			// read
+			clusterInStateFixture(ClusterStateTerminated),
+			libraryStatusFixture(fooJarStatus("PENDING")),
+		},
+		Resource: ResourceLibrary(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		jar = "dbfs:/foo.jar"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/jar:dbfs:/foo.jar", d.Id())
+}
+
+func TestLibraryCreate_RestartIfUninstallOnRestart(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("UNINSTALL_ON_RESTART")),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/restart",
+				ExpectedRequest: ClusterID{
+					ClusterID: "abc",
+				},
+			},
+			clusterInStateFixture(ClusterStateRunning),
+			installFooJar,
+			libraryStatusFixture(fooJarStatus("INSTALLED")),
+			// read
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("INSTALLED")),
+		},
+		Resource: ResourceLibrary(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		jar = "dbfs:/foo.jar"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/jar:dbfs:/foo.jar", d.Id())
+}
+
+func TestLibraryCreate_Failed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(),
+			installFooJar,
+			libraryStatusFixture(
+				fooJarStatus("FAILED", "file not found"),
+				libraries.LibraryStatus{
+					Library: &libraries.Library{
+						Whl: "dbfs:/other.whl",
+					},
+					Status: "PENDING",
+				}),
+		},
+		Resource: ResourceLibrary(),
+		Create:   true,
+		HCL: `
+		cluster_id = "abc"
+		jar = "dbfs:/foo.jar"`,
+	}.ExpectError(t, "jar:dbfs:/foo.jar failed: file not found")
+}
+
+func TestLibraryRead_UninstallOnRestart(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("UNINSTALL_ON_RESTART")),
+		},
+		Resource: ResourceLibrary(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc/jar:dbfs:/foo.jar",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestLibraryRead_Failed(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("FAILED", "file not found")),
+		},
+		Resource: ResourceLibrary(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc/jar:dbfs:/foo.jar",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.Id(), "failed library has to be installed again")
+}
+
+func TestLibraryRead_Installing(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clusterInStateFixture(ClusterStateRunning),
+			libraryStatusFixture(fooJarStatus("INSTALLING")),
+		},
+		Resource: ResourceLibrary(),
+		Read:     true,
+		New:      true,
+		ID:       "abc/jar:dbfs:/foo.jar",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/jar:dbfs:/foo.jar", d.Id())
+	assert.Equal(t, "dbfs:/foo.jar", d.Get("jar"))
+}
+
+func TestLibraryRead_InvalidID(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceLibrary(),
+		Read:     true,
+		ID:       "abc",
+	}.ExpectError(t, "invalid library ID: abc")
+}
+
+func TestLibraryDelete(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			libraryStatusFixture(fooJarStatus("INSTALLED")),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/uninstall",
+				ExpectedRequest: libraries.ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []libraries.Library{
+						{
+							Jar: "dbfs:/foo.jar",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceLibrary(),
+		Delete:   true,
+		ID:       "abc/jar:dbfs:/foo.jar",
+	}.ApplyNoError(t)
+}
+
+func TestLibraryDelete_ClusterMissing(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Status:   404,
+				Response: common.NotFound("Cluster abc does not exist"),
+			},
+		},
+		Resource: ResourceLibrary(),
+		Delete:   true,
+		ID:       "abc/jar:dbfs:/foo.jar",
+	}.ApplyNoError(t)
+}
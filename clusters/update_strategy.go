@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Strategies to apply configuration changes to a running cluster
+const (
+	// UpdateStrategyRestart edits the cluster right away, which restarts it
+	UpdateStrategyRestart = "restart"
+	// UpdateStrategyWaitForIdle waits until there are no active workloads on the cluster
+	UpdateStrategyWaitForIdle = "wait_for_idle"
+	// UpdateStrategyOnNextTermination leaves running cluster as is, so that the change
+	// is applied once the cluster is terminated
+	UpdateStrategyOnNextTermination = "on_next_termination"
+)
+
+// clusterIdlePeriod is the time without any activity on the cluster, after which it's
+// considered to be idle
+var clusterIdlePeriod = 5 * time.Minute
+
+type activeRunsRequest struct {
+	ActiveOnly bool  `url:"active_only,omitempty"`
+	Offset     int32 `url:"offset,omitempty"`
+	Limit      int32 `url:"limit,omitempty"`
+}
+
+type clusterInstance struct {
+	ClusterID string `json:"cluster_id,omitempty"`
+}
+
+type activeRun struct {
+	RunID           int64           `json:"run_id"`
+	ClusterInstance clusterInstance `json:"cluster_instance"`
+}
+
+type activeRunsList struct {
+	Runs    []activeRun `json:"runs"`
+	HasMore bool        `json:"has_more"`
+}
+
+// activeJobRuns returns identifiers of job runs, that are running on the cluster.
+// Jobs API is called directly, because jobs package depends on this one.
+func (a ClustersAPI) activeJobRuns(clusterID string) (runIDs []int64, err error) {
+	request := activeRunsRequest{
+		ActiveOnly: true,
+		Limit:      25,
+	}
+	for {
+		var runs activeRunsList
+		err = a.client.Get(a.context, "/jobs/runs/list", request, &runs)
+		if err != nil {
+			return
+		}
+		for _, run := range runs.Runs {
+			if run.ClusterInstance.ClusterID == clusterID {
+				runIDs = append(runIDs, run.RunID)
+			}
+		}
+		if !runs.HasMore {
+			return
+		}
+		request.Offset += int32(len(runs.Runs))
+	}
+}
+
+// busyReason returns the reason why the cluster cannot be restarted without interrupting
+// workloads, or empty string if the cluster is idle
+func (a ClustersAPI) busyReason(clusterID string) (string, error) {
+	runIDs, err := a.activeJobRuns(clusterID)
+	if err != nil {
+		return "", err
+	}
+	if len(runIDs) > 0 {
+		return fmt.Sprintf("job runs %v are active", runIDs), nil
+	}
+	clusterInfo, err := a.Get(clusterID)
+	if err != nil {
+		return "", err
+	}
+	if !clusterInfo.IsRunningOrResizing() {
+		return "", nil
+	}
+	since := time.Now().Add(-clusterIdlePeriod)
+	if clusterInfo.LastActivityTime > since.UnixNano()/int64(time.Millisecond) {
+		return fmt.Sprintf("there was activity within last %s", clusterIdlePeriod), nil
+	}
+	// upsizing is triggered by the load on the cluster
+	events, err := a.Events(EventsRequest{
+		ClusterID:  clusterID,
+		StartTime:  since.UnixNano() / int64(time.Millisecond),
+		Order:      SortDescending,
+		EventTypes: []ClusterEventType{EvTypeResizing},
+		MaxItems:   1,
+		Limit:      1,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, event := range events {
+		if event.Details.TargetNumWorkers > event.Details.CurrentNumWorkers {
+			return fmt.Sprintf("cluster is upsizing to %d workers",
+				event.Details.TargetNumWorkers), nil
+		}
+	}
+	return "", nil
+}
+
+// waitForIdle waits until there are no active job runs and no recent activity on the cluster
+func (a ClustersAPI) waitForIdle(clusterID string, timeout time.Duration) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		reason, err := a.busyReason(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if reason != "" {
+			log.Printf("[INFO] Waiting for %s to become idle: %s", clusterID, reason)
+			return resource.RetryableError(fmt.Errorf("%s is not idle: %s", clusterID, reason))
+		}
+		return nil
+	})
+}
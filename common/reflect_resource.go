@@ -84,6 +84,29 @@ func StructToSchema(v interface{}, customize func(map[string]*schema.Schema) map
 	return scm
 }
 
+// ComputedSchema marks all fields and nested blocks as computed, which is useful
+// for data sources, that return entities with the same structure as resources.
+// Optional flag is kept, so that omitempty fields remain consistent with schema.
+func ComputedSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, v := range s {
+		v.Computed = true
+		v.Required = false
+		v.ForceNew = false
+		v.Default = nil
+		v.MaxItems = 0
+		v.ValidateFunc = nil
+		v.ValidateDiagFunc = nil
+		v.DiffSuppressFunc = nil
+		v.ConflictsWith = nil
+		v.ExactlyOneOf = nil
+		v.AtLeastOneOf = nil
+		if nested, ok := v.Elem.(*schema.Resource); ok {
+			ComputedSchema(nested.Schema)
+		}
+	}
+	return s
+}
+
 func handleOptional(typeField reflect.StructField, schema *schema.Schema) {
 	if strings.Contains(typeField.Tag.Get("json"), "omitempty") {
 		schema.Optional = true
@@ -279,12 +302,13 @@ func collectionToMaps(v interface{}, s *schema.Schema) ([]interface{}, error) {
 		return nil, fmt.Errorf("not resource")
 	}
 	var allItems []reflect.Value
-	if s.MaxItems == 1 {
-		allItems = append(allItems, reflect.ValueOf(v))
+	rv := reflect.ValueOf(v)
+	if s.MaxItems == 1 || rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Struct {
+		// computed-only blocks cannot have MaxItems, so kind is checked as well
+		allItems = append(allItems, rv)
 	} else {
-		vs := reflect.ValueOf(v)
-		for i := 0; i < vs.Len(); i++ {
-			allItems = append(allItems, vs.Index(i))
+		for i := 0; i < rv.Len(); i++ {
+			allItems = append(allItems, rv.Index(i))
 		}
 	}
 	for _, v := range allItems {
@@ -317,6 +317,25 @@ func TestCollectionToMaps(t *testing.T) {
 	assert.EqualError(t, err, "not resource")
 }
 
+func TestComputedSchema(t *testing.T) {
+	s := ComputedSchema(StructToSchema(testStruct{}, nil))
+	assert.NoError(t, schema.InternalMap(s).InternalValidate(nil))
+	for _, field := range []string{"non_optional", "integer", "ptr_item", "slice_set_struct"} {
+		assert.Truef(t, s[field].Computed, "computed should be set to true in field: %s", field)
+		assert.Falsef(t, s[field].Required, "required should be set to false in field: %s", field)
+		assert.Falsef(t, s[field].ForceNew, "force_new should be set to false in field: %s", field)
+	}
+	assert.Nil(t, s["integer"].Default)
+	assert.Equal(t, 0, s["ptr_item"].MaxItems)
+	nested := s["slice_set_struct"].Elem.(*schema.Resource).Schema
+	assert.True(t, nested["nested"].Computed)
+	assert.True(t, nested["nested"].Elem.(*schema.Resource).Schema["slice_item"].Computed)
+
+	data, err := collectionToMaps(&testPtr{PtrItem: "a"}, s["ptr_item"])
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"slice_item": "a"}}, data)
+}
+
 func TestStructToData(t *testing.T) {
 	s := StructToSchema(Dummy{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		return s
@@ -2,6 +2,8 @@ package common
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"regexp"
 	"strings"
@@ -23,19 +25,50 @@ type Resource struct {
 	Timeouts       *schema.ResourceTimeout
 }
 
+type warning struct {
+	message string
+}
+
+func (w warning) Error() string {
+	return w.message
+}
+
+// Warningf is returned from Create or Update, when resource was changed, but there's
+// something to tell the user about, so that it's shown as a warning and apply doesn't fail
+func Warningf(format string, a ...interface{}) error {
+	return warning{fmt.Sprintf(format, a...)}
+}
+
+func toDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var w warning
+	if errors.As(err, &w) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Warning,
+				Summary:  w.Error(),
+			},
+		}
+	}
+	return diag.FromErr(err)
+}
+
 // ToResource converts to Terraform resource definition
 func (r Resource) ToResource() *schema.Resource {
 	var update func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics
 	if r.Update != nil {
 		update = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			c := m.(*DatabricksClient)
-			if err := r.Update(ctx, d, c); err != nil {
-				return diag.FromErr(err)
+			diags := toDiagnostics(r.Update(ctx, d, c))
+			if diags.HasError() {
+				return diags
 			}
 			if err := r.Read(ctx, d, c); err != nil {
-				return diag.FromErr(err)
+				return append(diags, diag.FromErr(err)...)
 			}
-			return nil
+			return diags
 		}
 	} else {
 		// set ForceNew to all attributes with CRD
@@ -66,14 +99,14 @@ func (r Resource) ToResource() *schema.Resource {
 		CustomizeDiff:  r.CustomizeDiff,
 		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			c := m.(*DatabricksClient)
-			err := r.Create(ctx, d, c)
-			if err != nil {
-				return diag.FromErr(err)
+			diags := toDiagnostics(r.Create(ctx, d, c))
+			if diags.HasError() {
+				return diags
 			}
-			if err = r.Read(ctx, d, c); err != nil {
-				return diag.FromErr(err)
+			if err := r.Read(ctx, d, c); err != nil {
+				return append(diags, diag.FromErr(err)...)
 			}
-			return nil
+			return diags
 		},
 		ReadContext:   read,
 		UpdateContext: update,
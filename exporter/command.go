@@ -67,6 +67,11 @@ func Run(args ...string) error {
 	flags.BoolVar(&ic.mounts, "mounts", false, "List DBFS mount points.")
 	flags.BoolVar(&ic.generateDeclaration, "generateProviderDeclaration", false,
 		"Generate Databricks provider declaration (for Terraform >= 0.13).")
+	flags.BoolVar(&ic.generateState, "generateState", false,
+		"Generate terraform.tfstate with all exported resources, so that running import.sh is not necessary.")
+	flags.BoolVar(&ic.portable, "portable", false,
+		"Replace workspace-specific node types, runtime versions and instance profiles "+
+			"with data sources and variables, so that code could be applied to other workspaces.")
 	services, listing := ic.allServicesAndListing()
 	flags.StringVar(&ic.services, "services", services,
 		"Comma-separated list of services to import. By default all services are imported.")
@@ -77,6 +82,21 @@ func Run(args ...string) error {
 	flags.StringVar(&ic.match, "match", "", "Match resource names during listing operation. "+
 		"This filter applies to all resources that are getting listed, so if you want to import "+
 		"all dependencies of just one cluster, specify -listing=compute")
+	flags.Var(keyValueFlag(ic.filters.Include), "include",
+		"Regular expression of names to include for a service, like -include=compute=^team-a. "+
+			"Could be specified multiple times.")
+	flags.Var(keyValueFlag(ic.filters.Exclude), "exclude",
+		"Regular expression of names to exclude for a service, like -exclude=jobs=(?i)test. "+
+			"Could be specified multiple times.")
+	flags.Var(keyValueFlag(ic.filters.Tags), "tag",
+		"Custom tag in key=value form, that listed clusters, instance pools and jobs must have. "+
+			"Could be specified multiple times.")
+	flags.Var(listFlag{&ic.filters.Owners}, "owner",
+		"Comma-separated list of users, that created listed clusters and jobs or own "+
+			"workspace paths in their home folders.")
+	filtersFile := ""
+	flags.StringVar(&filtersFile, "filters", "",
+		"Path to JSON file with include, exclude, tags and owners filters.")
 	prefix := ""
 	flags.StringVar(&prefix, "prefix", "", "Prefix that will be added to the name of all exported resources")
 	newArgs := args
@@ -90,6 +110,9 @@ func Run(args ...string) error {
 	if len(prefix) > 0 {
 		ic.prefix = prefix + "_"
 	}
+	if err = ic.filters.load(filtersFile); err != nil {
+		return err
+	}
 	if ic.debug {
 		logLevel = append(logLevel, "[DEBUG]")
 	}
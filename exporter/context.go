@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/commands"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/identity"
@@ -53,6 +54,7 @@ type importContext struct {
 	State       stateApproximation
 	Importables map[string]importable
 	Resources   map[string]*schema.Resource
+	DataSources map[string]*schema.Resource
 	Scope       importedResources
 	Files       map[string]*hclwrite.File
 	Directory   string
@@ -64,15 +66,27 @@ type importContext struct {
 	mountMap    map[string]mount
 	variables   map[string]string
 
+	// example values for variables, that are written to terraform.tfvars.example
+	variableValues map[string]string
+	// names of data sources, that were already generated in portable mode
+	portableData  map[string]bool
+	nodeTypes     *clusters.NodeTypeList
+	sparkVersions *clusters.SparkVersionsList
+	// resources, that are written to terraform.tfstate
+	stateResources []stateResource
+
 	debug               bool
 	mounts              bool
 	services            string
 	listing             string
 	match               string
+	filters             filterSpec
 	lastActiveDays      int64
 	generateDeclaration bool
+	generateState       bool
 	meAdmin             bool
 	prefix              string
+	portable            bool
 }
 
 type mount struct {
@@ -99,6 +113,7 @@ func newImportContext(c *common.DatabricksClient) *importContext {
 		State:       stateApproximation{},
 		Importables: resourcesMap,
 		Resources:   p.ResourcesMap,
+		DataSources: p.DataSourcesMap,
 		Files:       map[string]*hclwrite.File{},
 		Scope:       []*resource{},
 		importing:   map[string]bool{},
@@ -113,8 +128,11 @@ func newImportContext(c *common.DatabricksClient) *importContext {
 		},
 		hclFixes: []regexFix{ // Be careful with that! it may break working code
 		},
-		allUsers:  []identity.ScimUser{},
-		variables: map[string]string{},
+		allUsers:       []identity.ScimUser{},
+		variables:      map[string]string{},
+		variableValues: map[string]string{},
+		portableData:   map[string]bool{},
+		filters:        newFilterSpec(),
 	}
 }
 
@@ -204,7 +222,9 @@ func (ic *importContext) Run() error {
 		if ir.Ignore != nil && ir.Ignore(ic, r) {
 			continue
 		}
-		body := f.Body()
+		// every resource is generated separately to find its dependencies for the state
+		rf := hclwrite.NewEmptyFile()
+		body := rf.Body()
 		if ir.Body != nil {
 			err := ir.Body(ic, body, r)
 			if err != nil {
@@ -218,10 +238,19 @@ func (ic *importContext) Run() error {
 				log.Printf("[ERROR] %s", err.Error())
 			}
 		}
+		f.Body().AppendUnstructuredTokens(body.BuildTokens(nil))
+		inState := false
+		if ic.generateState && r.Mode != "data" {
+			inState, err = ic.addToState(r, rf.Bytes())
+			if err != nil {
+				log.Printf("[ERROR] Cannot add %s to state: %v", r, err)
+			}
+		}
 		if i%50 == 0 {
 			log.Printf("[INFO] Generated %d of %d resources", i, scopeSize)
 		}
-		if r.Mode != "data" {
+		// resources in generated state would fail with "Resource already managed by Terraform"
+		if r.Mode != "data" && !inState {
 			// nolint
 			sh.WriteString(r.ImportCommand(ic) + "\n")
 		}
@@ -249,13 +278,18 @@ func (ic *importContext) Run() error {
 		defer vf.Close()
 		f := hclwrite.NewEmptyFile()
 		body := f.Body()
-		for k, v := range ic.variables {
+		for _, k := range ic.variableNames() {
 			b := body.AppendNewBlock("variable", []string{k}).Body()
-			b.SetAttributeValue("description", cty.StringVal(v))
+			b.SetAttributeValue("description", cty.StringVal(ic.variables[k]))
 		}
 		// nolint
 		vf.Write(f.Bytes())
 		log.Printf("[INFO] Written %d variables", len(ic.variables))
+		if ic.portable {
+			if err = ic.writeTfvarsExample(); err != nil {
+				return err
+			}
+		}
 	}
 	cmd := exec.CommandContext(context.Background(), "terraform", "fmt")
 	cmd.Dir = ic.Directory
@@ -263,6 +297,12 @@ func (ic *importContext) Run() error {
 	if err != nil {
 		return err
 	}
+	// state is written only for configuration, that terraform was able to read
+	if ic.generateState {
+		if err = ic.writeState(); err != nil {
+			return err
+		}
+	}
 	log.Printf("[INFO] Done. Please edit the files and roll out new environment.")
 	return nil
 }
@@ -274,6 +314,11 @@ func (ic *importContext) MatchesName(n string) bool {
 	return strings.Contains(strings.ToLower(n), strings.ToLower(ic.match))
 }
 
+// isIncluded checks name of listed item against -match and service-specific filters
+func (ic *importContext) isIncluded(service, n string) bool {
+	return ic.MatchesName(n) && ic.filters.matchesName(service, n)
+}
+
 func (ic *importContext) Find(r *resource, pick string) hcl.Traversal {
 	for _, sr := range ic.State.Resources {
 		if sr.Type != r.Resource {
@@ -453,6 +498,8 @@ func (ic *importContext) Emit(r *resource) {
 // TODO: move to IC
 var dependsRe = regexp.MustCompile(`(\.[\d]+)`)
 
+var variableNameRegex = regexp.MustCompile(`[^0-9A-Za-z_]`)
+
 func (ic *importContext) reference(i importable, path []string, value string) hclwrite.Tokens {
 	match := dependsRe.ReplaceAllString(strings.Join(path, "."), "")
 	for _, d := range i.Depends {
@@ -474,10 +521,16 @@ func (ic *importContext) reference(i importable, path []string, value string) hc
 		}
 		return hclwrite.TokensForTraversal(traversal)
 	}
+	if ic.portable {
+		if toks := ic.portableValue(i, path, value); toks != nil {
+			return toks
+		}
+	}
 	return hclwrite.TokensForValue(cty.StringVal(value))
 }
 
 func (ic *importContext) variable(name, desc string) hclwrite.Tokens {
+	name = variableNameRegex.ReplaceAllString(name, "_")
 	ic.variables[name] = desc
 	return hclwrite.TokensForTraversal(hcl.Traversal{
 		hcl.TraverseRoot{Name: "var"},
@@ -485,6 +538,15 @@ func (ic *importContext) variable(name, desc string) hclwrite.Tokens {
 	})
 }
 
+func (ic *importContext) variableNames() []string {
+	names := []string{}
+	for k := range ic.variables {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type fieldTuple struct {
 	Field  string
 	Schema *schema.Schema
@@ -503,7 +565,7 @@ func (ic *importContext) dataToHcl(i importable, path []string,
 	})
 	for _, tuple := range ss {
 		a, as := tuple.Field, tuple.Schema
-		if as.Computed {
+		if as.Computed && !ic.isPortableNodeType(path, a, d) {
 			continue
 		}
 		raw, ok := d.GetOk(strings.Join(append(path, a), "."))
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,10 +19,14 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/jobs"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
 	"github.com/databrickslabs/terraform-provider-databricks/policies"
+	"github.com/databrickslabs/terraform-provider-databricks/pools"
 	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/databrickslabs/terraform-provider-databricks/sqlanalytics"
+	"github.com/databrickslabs/terraform-provider-databricks/sqlanalytics/api"
 	"github.com/databrickslabs/terraform-provider-databricks/workspace"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,157 +42,191 @@ func getJSONObject(filename string) interface{} {
 	return obj
 }
 
-func TestImportingMounts(t *testing.T) {
-	qa.HTTPFixturesApply(t,
-		[]qa.HTTPFixture{
-			{
-				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Me",
-				Response: identity.ScimUser{},
-			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/list",
-				Response: clusters.ClusterList{
-					Clusters: []clusters.ClusterInfo{
-						{
-							ClusterName: "terraform-mount",
-							ClusterID:   "mount",
-						},
-						{
-							ClusterName: "terraform-mount-shard-s3-access",
-							ClusterID:   "mount",
-						},
+// mountsFixtures returns fixtures for listing of the given mounts through the mounting cluster
+func mountsFixtures(mounts string) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Me",
+			Response: identity.ScimUser{},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list",
+			Response: clusters.ClusterList{
+				Clusters: []clusters.ClusterInfo{
+					{
+						ClusterName: "terraform-mount",
+						ClusterID:   "mount",
+					},
+					{
+						ClusterName: "terraform-mount-shard-s3-access",
+						ClusterID:   "mount",
 					},
 				},
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/get?cluster_id=mount",
-				Response: clusters.ClusterInfo{
-					State:       "RUNNING",
-					ClusterID:   "mount",
-					ClusterName: "dummy",
-				},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=mount",
+			Response: clusters.ClusterInfo{
+				State:       "RUNNING",
+				ClusterID:   "mount",
+				ClusterName: "dummy",
 			},
-			{
-				Method:       "POST",
-				ReuseRequest: true,
-				Resource:     "/api/1.2/contexts/create",
-				Response: commands.Command{
-					ID: "context",
-				},
+		},
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/contexts/create",
+			Response: commands.Command{
+				ID: "context",
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/1.2/contexts/status?clusterId=mount&contextId=context",
-				Response: commands.Command{
-					Status: "Running",
-				},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/contexts/status?clusterId=mount&contextId=context",
+			Response: commands.Command{
+				Status: "Running",
 			},
-			{
-				Method:       "POST",
-				ReuseRequest: true,
-				Resource:     "/api/1.2/commands/execute",
-				Response: commands.Command{
-					ID: "run",
-				},
+		},
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/execute",
+			Response: commands.Command{
+				ID: "run",
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/1.2/commands/status?clusterId=mount&commandId=run&contextId=context",
-				Response: commands.Command{
-					Status: "Finished",
-					Results: &common.CommandResults{
-						ResultType: "text",
-						Data: `{"foo": "s3a://foo", "bar": "abfss://bar@baz.com/thing", "third": "adls://foo.bar.com/path"}
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/1.2/commands/status?clusterId=mount&commandId=run&contextId=context",
+			Response: commands.Command{
+				Status: "Finished",
+				Results: &common.CommandResults{
+					ResultType: "text",
+					Data: mounts + `
 					and some chatty messages`,
-					},
 				},
 			},
-			{
-				Method:       "POST",
-				Resource:     "/api/1.2/contexts/destroy",
-				ReuseRequest: true,
-			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/instance-profiles/list",
-				Response: identity.InstanceProfileList{
-					InstanceProfiles: []identity.InstanceProfileInfo{
-						{
-							InstanceProfileArn: "arn:aws:iam::12345:instance-profile/shard-s3-access",
-						},
+		},
+		{
+			Method:       "POST",
+			Resource:     "/api/1.2/contexts/destroy",
+			ReuseRequest: true,
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/instance-profiles/list",
+			Response: identity.InstanceProfileList{
+				InstanceProfiles: []identity.InstanceProfileInfo{
+					{
+						InstanceProfileArn: "arn:aws:iam::12345:instance-profile/shard-s3-access",
 					},
 				},
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/spark-versions",
-				Response: clusters.SparkVersionsList{
-					SparkVersions: []clusters.SparkVersion{
-						{
-							Version: "Foo LTS",
-						},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/spark-versions",
+			Response: clusters.SparkVersionsList{
+				SparkVersions: []clusters.SparkVersion{
+					{
+						Version: "Foo LTS",
 					},
 				},
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/list-node-types",
-				Response: clusters.NodeTypeList{
-					NodeTypes: []clusters.NodeType{
-						{
-							NodeTypeID: "m5d.large",
-						},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list-node-types",
+			Response: clusters.NodeTypeList{
+				NodeTypes: []clusters.NodeType{
+					{
+						NodeTypeID: "m5d.large",
 					},
 				},
 			},
-			{
-				Method:       "POST",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/clusters/events",
-				Response: clusters.EventsResponse{
-					Events: []clusters.ClusterEvent{},
-				},
+		},
+		{
+			Method:       "POST",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/events",
+			Response: clusters.EventsResponse{
+				Events: []clusters.ClusterEvent{},
 			},
-			{
-				Method:       "GET",
-				ReuseRequest: true,
-				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=mount",
-				Response: libraries.ClusterLibraryList{
-					Libraries: []libraries.Library{},
-				},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/libraries/cluster-status?cluster_id=mount",
+			Response: libraries.ClusterLibraryList{
+				Libraries: []libraries.Library{},
 			},
-		}, func(ctx context.Context, client *common.DatabricksClient) {
+		},
+	}
+}
+
+func TestImportingMounts(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		mountsFixtures(`{"foo": "s3a://foo", "bar": "abfss://bar@baz.com/thing", "third": "adls://foo.bar.com/path"}`),
+		func(ctx context.Context, client *common.DatabricksClient) {
 			ic := newImportContext(client)
 			ic.services = "mounts"
 			ic.listing = "mounts"
 			ic.mounts = true
 
-			err := ic.Importables["databricks_aws_s3_mount"].List(ic)
+			err := ic.Importables["databricks_mount"].List(ic)
 			assert.NoError(t, err)
+			assert.Len(t, ic.Scope, 3)
 
-			err = ic.Importables["databricks_azure_adls_gen2_mount"].List(ic)
-			assert.NoError(t, err)
-			err = ic.Importables["databricks_azure_adls_gen2_mount"].Body(ic,
-				hclwrite.NewEmptyFile().Body(), ic.Scope[1])
-			assert.NoError(t, err)
+			for _, r := range ic.Scope {
+				err = ic.Importables["databricks_mount"].Body(ic,
+					hclwrite.NewEmptyFile().Body(), r)
+				assert.NoError(t, err)
+			}
 
-			err = ic.Importables["databricks_azure_adls_gen1_mount"].List(ic)
-			assert.NoError(t, err)
-			err = ic.Importables["databricks_azure_adls_gen1_mount"].Body(ic,
-				hclwrite.NewEmptyFile().Body(), ic.Scope[2])
+			//Run("-listing", "mounts", "-mounts")
+		})
+}
+
+func TestImportingMounts_GenericBlocks(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		mountsFixtures(`{"foo": "s3a://foo", "bar": "abfss://bar@baz.com/thing", "third": "adls://foo.bar.com/path", `+
+			`"fourth": "wasbs://container@account.blob.core.windows.net", "fifth": "gs://gcs-bucket", "sixth": "unknown://thing"}`),
+		func(ctx context.Context, client *common.DatabricksClient) {
+			ic := newImportContext(client)
+			ic.services = "mounts"
+			ic.listing = "mounts"
+			ic.mounts = true
+
+			err := ic.Importables["databricks_mount"].List(ic)
 			assert.NoError(t, err)
+			assert.Len(t, ic.Scope, 5, "unknown mount source is skipped")
 
-			//Run("-listing", "mounts", "-mounts")
+			f := hclwrite.NewEmptyFile()
+			for _, r := range ic.Scope {
+				err = ic.Importables["databricks_mount"].Body(ic, f.Body(), r)
+				assert.NoError(t, err)
+			}
+			hcl := regexp.MustCompile(`\s+`).ReplaceAllString(string(f.Bytes()), " ")
+			assert.Contains(t, hcl, `resource "databricks_mount" "foo"`)
+			assert.Contains(t, hcl, `bucket_name = "foo"`)
+			assert.Contains(t, hcl, `storage_account_name = "baz"`)
+			assert.Contains(t, hcl, `directory = "/thing"`)
+			assert.Contains(t, hcl, `client_secret_scope = var.client_secret_scope_baz_bar`)
+			assert.Contains(t, hcl, `storage_resource_name = "foo"`)
+			assert.Contains(t, hcl, `token_secret_key = var.token_secret_key_account_container`)
+			assert.Contains(t, hcl, `auth_type = var.auth_type_account_container`)
+			assert.Contains(t, hcl, `bucket_name = "gcs-bucket"`)
+			assert.Contains(t, hcl, `service_account = var.service_account_gcs_bucket`)
 		})
 }
 
@@ -210,10 +250,18 @@ var repoListFixture = qa.HTTPFixture{
 	Response:     workspace.ReposListResponse{},
 }
 
+var emptyInstancePoolsFixture = qa.HTTPFixture{
+	Method:       "GET",
+	ReuseRequest: true,
+	Resource:     "/api/2.0/instance-pools/list",
+	Response:     pools.InstancePoolList{},
+}
+
 func TestImportingUsersGroupsSecretScopes(t *testing.T) {
 	qa.HTTPFixturesApply(t,
 		[]qa.HTTPFixture{
 			meAdminFixture,
+			emptyInstancePoolsFixture,
 			repoListFixture,
 			{
 				Method:   "GET",
@@ -290,7 +338,7 @@ func TestImportingUsersGroupsSecretScopes(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/jobs/list",
+				Resource: "/api/2.0/jobs/list?limit=25",
 				Response: jobs.JobList{},
 			},
 			{
@@ -367,6 +415,7 @@ func TestImportingNoResourcesError(t *testing.T) {
 	qa.HTTPFixturesApply(t,
 		[]qa.HTTPFixture{
 			meAdminFixture,
+			emptyInstancePoolsFixture,
 			repoListFixture,
 			{
 				Method:   "GET",
@@ -383,7 +432,7 @@ func TestImportingNoResourcesError(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/jobs/list",
+				Resource: "/api/2.0/jobs/list?limit=25",
 				Response: jobs.JobList{},
 			},
 			{
@@ -418,6 +467,7 @@ func TestImportingClusters(t *testing.T) {
 	qa.HTTPFixturesApply(t,
 		[]qa.HTTPFixture{
 			meAdminFixture,
+			emptyInstancePoolsFixture,
 			repoListFixture,
 			{
 				Method:   "GET",
@@ -426,7 +476,7 @@ func TestImportingClusters(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/jobs/list",
+				Resource: "/api/2.0/jobs/list?limit=25",
 				Response: jobs.JobList{},
 			},
 			{
@@ -547,14 +597,114 @@ func TestImportingClusters(t *testing.T) {
 			ic := newImportContext(client)
 			ic.Directory = tmpDir
 			ic.listing = "compute"
+			ic.generateState = true
 			services, _ := ic.allServicesAndListing()
 			ic.services = services
 
 			err := ic.Run()
 			assert.NoError(t, err)
+
+			raw, err := ioutil.ReadFile(tmpDir + "/terraform.tfstate")
+			assert.NoError(t, err)
+			var state stateV4
+			err = json.Unmarshal(raw, &state)
+			assert.NoError(t, err)
+			assert.Equal(t, 4, state.Version)
+			assert.Len(t, state.Lineage, 36)
+			found := false
+			for _, r := range state.Resources {
+				assert.Equal(t, `provider["registry.terraform.io/databrickslabs/databricks"]`, r.Provider)
+				assert.Equal(t, "managed", r.Mode)
+				if r.Type != "databricks_cluster" || r.Name != "aws_cluster_awscluster" {
+					continue
+				}
+				found = true
+				assert.Equal(t, []string{"databricks_instance_profile.shard_s3_access"},
+					r.Instances[0].Dependencies)
+				var attrs map[string]interface{}
+				err = json.Unmarshal(r.Instances[0].Attributes, &attrs)
+				assert.NoError(t, err)
+				assert.Equal(t, "awscluster", attrs["id"])
+				assert.Equal(t, "AWS cluster", attrs["cluster_name"])
+			}
+			assert.True(t, found, "cluster must be in state")
+
+			importSh, err := ioutil.ReadFile(tmpDir + "/import.sh")
+			assert.NoError(t, err)
+			inState := map[string]bool{}
+			for _, r := range state.Resources {
+				inState[r.Type+"."+r.Name] = true
+			}
+			for _, r := range ic.Scope {
+				ir := ic.Importables[r.Resource]
+				if r.Mode == "data" || (ir.Ignore != nil && ir.Ignore(ic, r)) {
+					continue
+				}
+				if inState[r.Resource+"."+r.Name] {
+					assert.NotContains(t, string(importSh), r.ImportCommand(ic),
+						"resources in state must not be imported again")
+				} else {
+					assert.Contains(t, string(importSh), r.ImportCommand(ic))
+				}
+			}
+
+			assert.Equal(t, "1.0.9", state.TerraformVersion)
+
+			err = ic.writeState()
+			assert.EqualError(t, err, fmt.Sprintf("%s/terraform.tfstate already exists", tmpDir))
 		})
 }
 
+func TestParseTerraformVersion(t *testing.T) {
+	version, err := parseTerraformVersion([]byte(`{"terraform_version": "1.0.9", "platform": "linux_amd64"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.9", version)
+
+	version, err = parseTerraformVersion([]byte("Terraform v0.15.5\non linux_amd64\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.15.5", version)
+
+	_, err = parseTerraformVersion([]byte(""))
+	assert.EqualError(t, err, "cannot parse terraform version: ")
+}
+
+func TestAddToState_IncompleteData(t *testing.T) {
+	ic := newImportContext(&common.DatabricksClient{})
+	mount := &resource{
+		Resource: "databricks_mount",
+		ID:       "foo",
+		Name:     "foo",
+		Data: ic.Resources["databricks_mount"].Data(&terraform.InstanceState{
+			ID:         "foo",
+			Attributes: map[string]string{},
+		}),
+	}
+	inState, err := ic.addToState(mount, []byte(`resource "databricks_mount" "foo" {}`))
+	assert.NoError(t, err)
+	assert.False(t, inState)
+	assert.Len(t, ic.stateResources, 0, "mount without attributes must be imported with import.sh")
+
+	group := &resource{
+		Resource: "databricks_group",
+		ID:       "123",
+		Name:     "foo",
+		Data: ic.Resources["databricks_group"].Data(&terraform.InstanceState{
+			ID:         "123",
+			Attributes: map[string]string{"allow_cluster_create": "true"},
+		}),
+	}
+	inState, err = ic.addToState(group, []byte(`resource "databricks_group" "foo" {}`))
+	assert.NoError(t, err)
+	assert.False(t, inState)
+	assert.Len(t, ic.stateResources, 0, "group without display_name must be imported with import.sh")
+
+	group.Data.Set("display_name", "foo")
+	inState, err = ic.addToState(group, []byte(`resource "databricks_group" "foo" {}`))
+	assert.NoError(t, err)
+	assert.True(t, inState)
+	assert.Len(t, ic.stateResources, 1)
+}
+
 func TestImportingJobs_JobList(t *testing.T) {
 	nowSeconds := time.Now().Unix()
 	jobRuns := jobs.JobRunsList{
@@ -570,7 +720,7 @@ func TestImportingJobs_JobList(t *testing.T) {
 			repoListFixture,
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/jobs/list",
+				Resource: "/api/2.0/jobs/list?limit=25",
 				Response: jobs.JobList{
 					Jobs: []jobs.Job{
 						{
@@ -772,6 +922,125 @@ func TestImportingJobs_JobList(t *testing.T) {
 		})
 }
 
+func TestImportingJobs_JobClusters(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/instance-pools/get?instance_pool_id=pool1",
+				ReuseRequest: true,
+				Response:     getJSONObject("test-data/get-instance-pool1.json"),
+			},
+		}, func(ctx context.Context, client *common.DatabricksClient) {
+			ic := newImportContext(client)
+			ic.services = "jobs,compute"
+			d := ic.Resources["databricks_job"].TestResourceData()
+			d.MarkNewResource()
+			err := common.StructToData(jobs.JobSettings{
+				Name: "Shared clusters",
+				JobClusters: []jobs.JobCluster{
+					{
+						JobClusterKey: "shared",
+						NewCluster: &clusters.Cluster{
+							InstancePoolID: "pool1",
+							NumWorkers:     2,
+							SparkVersion:   "6.4.x-scala2.11",
+						},
+					},
+				},
+				Tasks: []jobs.JobTaskSettings{
+					{
+						TaskKey:       "a",
+						JobClusterKey: "shared",
+						NotebookTask: &jobs.NotebookTask{
+							NotebookPath: "/A",
+						},
+					},
+				},
+			}, ic.Resources["databricks_job"].Schema, d)
+			assert.NoError(t, err)
+			err = resourcesMap["databricks_job"].Import(ic, &resource{
+				Resource: "databricks_job",
+				ID:       "14",
+				Data:     d,
+			})
+			assert.NoError(t, err)
+			assert.True(t, ic.Has(&resource{
+				Resource: "databricks_instance_pool",
+				ID:       "pool1",
+			}))
+		})
+}
+
+func TestImportingJobs_SqlTasks(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/queries/q1",
+				Response: api.Query{
+					ID:           "q1",
+					DataSourceID: "ds1",
+					Name:         "Daily revenue",
+					Query:        "SELECT 1",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/endpoints/w1",
+				Response: sqlanalytics.SQLEndpoint{
+					ID:          "w1",
+					Name:        "Analysts",
+					ClusterSize: "Small",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/sql/data_sources",
+				Response: []sqlanalytics.DataSource{
+					{
+						ID:         "ds1",
+						EndpointID: "w1",
+					},
+				},
+			},
+		}, func(ctx context.Context, client *common.DatabricksClient) {
+			ic := newImportContext(client)
+			ic.services = "jobs,sql"
+			d := ic.Resources["databricks_job"].TestResourceData()
+			d.MarkNewResource()
+			err := common.StructToData(jobs.JobSettings{
+				Name: "Reports",
+				Tasks: []jobs.JobTaskSettings{
+					{
+						TaskKey: "a",
+						SqlTask: &jobs.SqlTask{
+							Query: &jobs.SqlQueryTask{
+								QueryID: "q1",
+							},
+							WarehouseID: "w1",
+						},
+					},
+				},
+			}, ic.Resources["databricks_job"].Schema, d)
+			assert.NoError(t, err)
+			err = resourcesMap["databricks_job"].Import(ic, &resource{
+				Resource: "databricks_job",
+				ID:       "14",
+				Data:     d,
+			})
+			assert.NoError(t, err)
+			assert.True(t, ic.Has(&resource{
+				Resource: "databricks_sql_query",
+				ID:       "q1",
+			}))
+			assert.True(t, ic.Has(&resource{
+				Resource: "databricks_sql_endpoint",
+				ID:       "w1",
+			}))
+		})
+}
+
 func TestImportingWithError(t *testing.T) {
 	err := Run("-directory", "/bin/sh", "-services", "groups,users")
 	assert.EqualError(t, err, "the path /bin/sh is not a directory")
@@ -792,7 +1061,7 @@ func TestImportingSecrets(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/jobs/list",
+				Resource: "/api/2.0/jobs/list?limit=25",
 				Response: jobs.JobList{},
 			},
 			{
@@ -989,3 +1258,335 @@ func TestImportingRepos(t *testing.T) {
 			assert.NoError(t, err)
 		})
 }
+
+func TestImportingClustersPortable(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			meAdminFixture,
+			emptyInstancePoolsFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: getJSONObject("test-data/clusters-list-response.json"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=awscluster",
+				Response: getJSONObject("test-data/get-cluster-awscluster-response.json"),
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: clusters.EventDetails{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=awscluster",
+				Response: getJSONObject("test-data/libraries-cluster-status-test2.json"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list-node-types",
+				Response: clusters.NodeTypeList{
+					NodeTypes: []clusters.NodeType{
+						{
+							NodeTypeID:       "i3.2xlarge",
+							MemoryMB:         62464,
+							NumCores:         8,
+							Category:         "Storage Optimized",
+							IsIOCacheEnabled: true,
+							NodeInstanceType: &clusters.NodeInstanceType{
+								LocalNVMeDisks: 1,
+							},
+						},
+						{
+							NodeTypeID:       "i3.4xlarge",
+							MemoryMB:         124928,
+							NumCores:         16,
+							Category:         "Storage Optimized",
+							IsIOCacheEnabled: true,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/spark-versions",
+				Response: clusters.SparkVersionsList{
+					SparkVersions: []clusters.SparkVersion{
+						{
+							Version:     "7.3.x-cpu-ml-scala2.12",
+							Description: "7.3 LTS ML (includes Apache Spark 3.0.1, Scala 2.12)",
+						},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+			defer os.RemoveAll(tmpDir)
+
+			ic := newImportContext(client)
+			ic.Directory = tmpDir
+			ic.listing = "compute"
+			ic.services = "compute"
+			ic.match = "AWS"
+			ic.portable = true
+
+			err := ic.Run()
+			assert.NoError(t, err)
+
+			compute, err := ioutil.ReadFile(tmpDir + "/compute.tf")
+			assert.NoError(t, err)
+			assert.Contains(t, string(compute), "data.databricks_node_type.i3_2xlarge.id")
+			assert.Contains(t, string(compute), "data.databricks_node_type.i3_4xlarge.id")
+			assert.Contains(t, string(compute), "data.databricks_spark_version.dbr_7_3_x_cpu_ml_scala2_12.id")
+			assert.Contains(t, string(compute), "var.instance_profile_shard_s3_access")
+
+			data, err := ioutil.ReadFile(tmpDir + "/data.tf")
+			assert.NoError(t, err)
+			assert.Contains(t, string(data), `data "databricks_node_type" "i3_2xlarge"`)
+			assert.Contains(t, string(data), `"3.0.1"`)
+
+			tfvars, err := ioutil.ReadFile(tmpDir + "/terraform.tfvars.example")
+			assert.NoError(t, err)
+			assert.Contains(t, string(tfvars),
+				`instance_profile_shard_s3_access = "arn:aws:iam::12345:instance-profile/shard-s3-access"`)
+		})
+}
+
+func TestPortableValues(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=pool-123",
+				Response: pools.InstancePool{
+					InstancePoolID:   "pool-123",
+					InstancePoolName: "Shared Pool",
+				},
+			},
+		},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+			defer os.RemoveAll(tmpDir)
+
+			ic := newImportContext(client)
+			ic.Directory = tmpDir
+			ic.portable = true
+
+			reference := func(i importable, path, value string) string {
+				return string(ic.reference(i, strings.Split(path, "."), value).Bytes())
+			}
+			assert.Equal(t, "var.instance_pool_shared_pool",
+				reference(importable{}, "new_cluster.0.instance_pool_id", "pool-123"))
+			assert.Equal(t, "var.dbfs_filestore_jars_etl_jar",
+				reference(importable{}, "library.0.jar", "dbfs:/FileStore/jars/etl.jar"))
+			// spark-versions are not listed for data sources, otherwise fixture is missing
+			assert.Equal(t, `"3.0.1"`,
+				reference(importable{Service: dataSourcesService}, "spark_version", "3.0.1"))
+
+			ic.variable("secret_scope_key", "Secret key of scope")
+			err := os.MkdirAll(tmpDir, 0755)
+			assert.NoError(t, err)
+			err = ic.writeTfvarsExample()
+			assert.NoError(t, err)
+			tfvars, err := ioutil.ReadFile(tmpDir + "/terraform.tfvars.example")
+			assert.NoError(t, err)
+			assert.Regexp(t, `instance_pool_shared_pool\s+= "pool-123"`, string(tfvars))
+			assert.Regexp(t, `dbfs_filestore_jars_etl_jar\s+= "dbfs:/FileStore/jars/etl.jar"`, string(tfvars))
+			assert.Regexp(t, `secret_scope_key\s+= "<Secret key of scope>"`, string(tfvars))
+		})
+}
+
+func TestPortableReferences_PrefixWithDash(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			tmpDir := fmt.Sprintf("/tmp/tf-%s", qa.RandomName())
+			defer os.RemoveAll(tmpDir)
+
+			ic := newImportContext(client)
+			ic.Directory = tmpDir
+			ic.portable = true
+			ic.prefix = "team-a_"
+
+			assert.Equal(t, "var.team_a_dbfs_filestore_jars_etl_jar", string(ic.reference(importable{},
+				[]string{"library", "0", "jar"}, "dbfs:/FileStore/jars/etl.jar").Bytes()))
+
+			err := os.MkdirAll(tmpDir, 0755)
+			assert.NoError(t, err)
+			err = ic.writeTfvarsExample()
+			assert.NoError(t, err)
+			tfvars, err := ioutil.ReadFile(tmpDir + "/terraform.tfvars.example")
+			assert.NoError(t, err)
+			assert.Regexp(t, `team_a_dbfs_filestore_jars_etl_jar\s+= "dbfs:/FileStore/jars/etl.jar"`, string(tfvars))
+		})
+}
+
+func TestFilterSpec(t *testing.T) {
+	filtersFile := fmt.Sprintf("/tmp/tf-filters-%s.json", qa.RandomName())
+	err := ioutil.WriteFile(filtersFile, []byte(`{
+		"include": {"compute": "^team-a", "jobs": "^nope"},
+		"exclude": {"compute": "sandbox"},
+		"tags": {"Team": "a"},
+		"owners": ["first@example.com"]
+	}`), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(filtersFile)
+
+	f := newFilterSpec()
+	f.Include["jobs"] = "^team-a"
+	f.Owners = append(f.Owners, "second@example.com")
+	err = f.load(filtersFile)
+	assert.NoError(t, err)
+
+	assert.True(t, f.matchesName("compute", "team-a-etl"))
+	assert.False(t, f.matchesName("compute", "team-a-sandbox"))
+	assert.False(t, f.matchesName("compute", "team-b-etl"))
+	assert.True(t, f.matchesName("jobs", "team-a-etl"), "flags take precedence")
+	assert.True(t, f.matchesName("groups", "anything"))
+
+	assert.True(t, f.matchesTags(map[string]string{"Team": "a", "Env": "prod"}))
+	assert.False(t, f.matchesTags(map[string]string{"Team": "b"}))
+	assert.False(t, f.matchesTags(nil))
+	assert.True(t, f.matchesJobTags(&jobs.JobSettings{
+		Tasks: []jobs.JobTaskSettings{
+			{NewCluster: &clusters.Cluster{CustomTags: map[string]string{"Team": "a"}}},
+		},
+	}))
+	assert.False(t, f.matchesJobTags(&jobs.JobSettings{ExistingClusterID: "abc"}))
+
+	assert.True(t, f.listsInstancePools())
+	empty := newFilterSpec()
+	assert.False(t, empty.listsInstancePools(), "pools are listed only with filters")
+
+	assert.True(t, f.matchesOwner("First@Example.com"))
+	assert.True(t, f.matchesOwner("second@example.com"))
+	assert.False(t, f.matchesOwner("third@example.com"))
+	assert.True(t, f.matchesPath("/Users/first@example.com/notebook"))
+	assert.True(t, f.matchesPath("/Repos/second@example.com/project"))
+	assert.False(t, f.matchesPath("/Repos/third@example.com/project"))
+	assert.False(t, f.matchesPath("/Shared/notebook"))
+}
+
+func TestFilterSpecErrors(t *testing.T) {
+	f := newFilterSpec()
+	f.Exclude["compute"] = "(abc"
+	err := f.load("")
+	assert.EqualError(t, err, "invalid filter for compute service: "+
+		"error parsing regexp: missing closing ): `(abc`")
+
+	f = newFilterSpec()
+	err = f.load("/tmp/this/does/not/exist.json")
+	assert.Error(t, err)
+
+	err = keyValueFlag{}.Set("nope")
+	assert.EqualError(t, err, "expected key=value, but got nope")
+}
+
+func TestImportingClustersAndJobsFiltered(t *testing.T) {
+	qa.HTTPFixturesApply(t,
+		[]qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/list",
+				Response: clusters.ClusterList{
+					Clusters: []clusters.ClusterInfo{
+						{
+							ClusterID:        "a",
+							ClusterName:      "Team A",
+							CreatorUserName:  "a@example.com",
+							CustomTags:       map[string]string{"Team": "a"},
+							LastActivityTime: time.Now().Unix() * 1000,
+						},
+						{
+							ClusterID:        "b",
+							ClusterName:      "Team B",
+							CreatorUserName:  "a@example.com",
+							CustomTags:       map[string]string{"Team": "b"},
+							LastActivityTime: time.Now().Unix() * 1000,
+						},
+						{
+							ClusterID:        "c",
+							ClusterName:      "Team A, but someone else",
+							CreatorUserName:  "c@example.com",
+							CustomTags:       map[string]string{"Team": "a"},
+							LastActivityTime: time.Now().Unix() * 1000,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Response: pools.InstancePoolList{
+					InstancePools: []pools.InstancePoolAndStats{
+						{
+							InstancePoolID:   "pool-a",
+							InstancePoolName: "Pool A",
+							CustomTags:       map[string]string{"Team": "a"},
+						},
+						{
+							InstancePoolID:   "pool-b",
+							InstancePoolName: "Pool B",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/list?limit=25",
+				Response: jobs.JobList{
+					Jobs: []jobs.Job{
+						{
+							JobID:           1,
+							CreatorUserName: "a@example.com",
+							Settings: &jobs.JobSettings{
+								Name: "Nightly",
+								NewCluster: &clusters.Cluster{
+									CustomTags: map[string]string{"Team": "a"},
+								},
+							},
+						},
+						{
+							JobID:           2,
+							CreatorUserName: "a@example.com",
+							Settings: &jobs.JobSettings{
+								Name: "Nightly test",
+								NewCluster: &clusters.Cluster{
+									CustomTags: map[string]string{"Team": "a"},
+								},
+							},
+						},
+						{
+							JobID:           3,
+							CreatorUserName: "a@example.com",
+							Settings: &jobs.JobSettings{
+								Name:              "Hourly",
+								ExistingClusterID: "a",
+							},
+						},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, client *common.DatabricksClient) {
+			ic := newImportContext(client)
+			ic.lastActiveDays = 3650
+			ic.filters.Exclude["jobs"] = "test$"
+			ic.filters.Tags["Team"] = "a"
+			ic.filters.Owners = []string{"a@example.com"}
+			err := ic.filters.load("")
+			assert.NoError(t, err)
+
+			for _, r := range []string{"databricks_cluster", "databricks_instance_pool", "databricks_job"} {
+				err = ic.Importables[r].List(ic)
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, map[string]bool{
+				"databricks_cluster[<unknown>] (id: a)":            true,
+				"databricks_instance_pool[<unknown>] (id: pool-a)": true,
+				"databricks_job[<unknown>] (id: 1)":                true,
+			}, ic.importing)
+		})
+}
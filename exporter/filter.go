@@ -0,0 +1,205 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/jobs"
+)
+
+// filterSpec narrows down items, that are listed for export. It could be
+// read from JSON file and/or populated from command-line flags.
+type filterSpec struct {
+	// service name to regular expression of names to include
+	Include map[string]string `json:"include,omitempty"`
+	// service name to regular expression of names to exclude
+	Exclude map[string]string `json:"exclude,omitempty"`
+	// all of these custom tags must be present on clusters, pools or jobs
+	Tags map[string]string `json:"tags,omitempty"`
+	// user names of creators or owners
+	Owners []string `json:"owners,omitempty"`
+
+	include map[string]*regexp.Regexp
+	exclude map[string]*regexp.Regexp
+}
+
+func newFilterSpec() filterSpec {
+	return filterSpec{
+		Include: map[string]string{},
+		Exclude: map[string]string{},
+		Tags:    map[string]string{},
+		Owners:  []string{},
+	}
+}
+
+// load reads filter spec from JSON file, where values from command-line flags
+// take precedence over those from the file
+func (f *filterSpec) load(filename string) error {
+	if filename == "" {
+		return f.compile()
+	}
+	raw, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	fromFile := newFilterSpec()
+	if err = json.Unmarshal(raw, &fromFile); err != nil {
+		return fmt.Errorf("cannot parse %s: %w", filename, err)
+	}
+	for k, v := range fromFile.Include {
+		if _, ok := f.Include[k]; !ok {
+			f.Include[k] = v
+		}
+	}
+	for k, v := range fromFile.Exclude {
+		if _, ok := f.Exclude[k]; !ok {
+			f.Exclude[k] = v
+		}
+	}
+	for k, v := range fromFile.Tags {
+		if _, ok := f.Tags[k]; !ok {
+			f.Tags[k] = v
+		}
+	}
+	f.Owners = append(f.Owners, fromFile.Owners...)
+	return f.compile()
+}
+
+func (f *filterSpec) compile() (err error) {
+	compileAll := func(src map[string]string) (map[string]*regexp.Regexp, error) {
+		res := map[string]*regexp.Regexp{}
+		for service, expr := range src {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter for %s service: %w", service, err)
+			}
+			res[service] = re
+		}
+		return res, nil
+	}
+	if f.include, err = compileAll(f.Include); err != nil {
+		return
+	}
+	f.exclude, err = compileAll(f.Exclude)
+	return
+}
+
+// matchesName checks name of listed item against include and exclude regexes of a service
+func (f *filterSpec) matchesName(service, name string) bool {
+	if re, ok := f.include[service]; ok && !re.MatchString(name) {
+		return false
+	}
+	if re, ok := f.exclude[service]; ok && re.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+// matchesTags checks that all filtered tags are present with the same values
+func (f *filterSpec) matchesTags(tags map[string]string) bool {
+	for k, v := range f.Tags {
+		if tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesJobTags checks custom tags of job cluster or any of the task clusters
+func (f *filterSpec) matchesJobTags(js *jobs.JobSettings) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+	if js == nil {
+		return false
+	}
+	if js.NewCluster != nil && f.matchesTags(js.NewCluster.CustomTags) {
+		return true
+	}
+	for _, task := range js.Tasks {
+		if task.NewCluster != nil && f.matchesTags(task.NewCluster.CustomTags) {
+			return true
+		}
+	}
+	return false
+}
+
+// listsInstancePools tells if instance pools have to be listed, which happens only when compute
+// is narrowed down by tags or include filter. Otherwise pools are exported only as dependencies
+// of clusters and jobs.
+func (f *filterSpec) listsInstancePools() bool {
+	_, included := f.include["compute"]
+	return included || len(f.Tags) > 0
+}
+
+// matchesOwner checks creator or owner of an item, if any owners were specified
+func (f *filterSpec) matchesOwner(owner string) bool {
+	if len(f.Owners) == 0 {
+		return true
+	}
+	for _, o := range f.Owners {
+		if strings.EqualFold(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPath checks workspace path owner, like in /Users/<email>/ or /Repos/<email>/.
+// Only repos are listed by path, as notebooks aren't exported.
+func (f *filterSpec) matchesPath(path string) bool {
+	if len(f.Owners) == 0 {
+		return true
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 || (parts[0] != "Users" && parts[0] != "Repos") {
+		return false
+	}
+	return f.matchesOwner(parts[1])
+}
+
+// keyValueFlag is repeatable command-line flag in the form of key=value
+type keyValueFlag map[string]string
+
+func (kv keyValueFlag) String() string {
+	pairs := []string{}
+	for k, v := range kv {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv keyValueFlag) Set(value string) error {
+	split := strings.SplitN(value, "=", 2)
+	if len(split) != 2 || split[0] == "" {
+		return fmt.Errorf("expected key=value, but got %s", value)
+	}
+	kv[split[0]] = split[1]
+	return nil
+}
+
+// listFlag is repeatable command-line flag, that also accepts comma-separated values
+type listFlag struct {
+	values *[]string
+}
+
+func (l listFlag) String() string {
+	if l.values == nil {
+		return ""
+	}
+	return strings.Join(*l.values, ",")
+}
+
+func (l listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l.values = append(*l.values, v)
+		}
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/jobs"
+	"github.com/databrickslabs/terraform-provider-databricks/pools"
 	"github.com/databrickslabs/terraform-provider-databricks/workspace"
 
 	"github.com/databrickslabs/terraform-provider-databricks/storage"
@@ -27,9 +28,11 @@ import (
 )
 
 var (
-	//adlsGen2Regex = regexp.MustCompile(`^((?:abfs|wasb)s?)://([^@]+)@([^.]+)\.(?:[^/]+)(/.*)?$`)
 	adlsGen2Regex = regexp.MustCompile(`^(abfss?)://([^@]+)@([^.]+)\.(?:[^/]+)(/.*)?$`)
 	adlsGen1Regex = regexp.MustCompile(`^(adls?)://([^.]+)\.(?:[^/]+)(/.*)?$`)
+	wasbRegex     = regexp.MustCompile(`^(wasbs?)://([^@]+)@([^.]+)\.(?:[^/]+)(/.*)?$`)
+	s3Regex       = regexp.MustCompile(`^(s3a?)://([^/]+)(/.*)?$`)
+	gsRegex       = regexp.MustCompile(`^(gs)://([^/]+)(/.*)?$`)
 )
 
 var resourcesMap map[string]importable = map[string]importable{
@@ -87,6 +90,30 @@ var resourcesMap map[string]importable = map[string]importable{
 			}
 			return name
 		},
+		List: func(ic *importContext) error {
+			if !ic.filters.listsInstancePools() {
+				return nil
+			}
+			pools, err := pools.NewInstancePoolsAPI(ic.Context, ic.Client).List()
+			if err != nil {
+				return err
+			}
+			for offset, pool := range pools.InstancePools {
+				if !ic.isIncluded("compute", pool.InstancePoolName) {
+					continue
+				}
+				if !ic.filters.matchesTags(pool.CustomTags) {
+					log.Printf("[INFO] Skipping filtered instance pool %s", pool.InstancePoolName)
+					continue
+				}
+				ic.Emit(&resource{
+					Resource: "databricks_instance_pool",
+					ID:       pool.InstancePoolID,
+				})
+				log.Printf("[INFO] Scanned %d of %d instance pools", offset+1, len(pools.InstancePools))
+			}
+			return nil
+		},
 		Import: func(ic *importContext, r *resource) error {
 			if ic.meAdmin {
 				ic.Emit(&resource{
@@ -145,7 +172,12 @@ var resourcesMap map[string]importable = map[string]importable{
 					log.Printf("[INFO] Skipping terraform-specific cluster %s", c.ClusterName)
 					continue
 				}
-				if !ic.MatchesName(c.ClusterName) {
+				if !ic.isIncluded("compute", c.ClusterName) {
+					continue
+				}
+				if !ic.filters.matchesTags(c.CustomTags) ||
+					!ic.filters.matchesOwner(c.CreatorUserName) {
+					log.Printf("[INFO] Skipping filtered cluster %s", c.ClusterName)
 					continue
 				}
 				if c.LastActivityTime < time.Now().Unix()-lastActiveMs {
@@ -188,16 +220,24 @@ var resourcesMap map[string]importable = map[string]importable{
 			{Path: "email_notifications.on_failure", Resource: "databricks_user", Match: "user_name"},
 			{Path: "email_notifications.on_success", Resource: "databricks_user", Match: "user_name"},
 			{Path: "email_notifications.on_start", Resource: "databricks_user", Match: "user_name"},
+			{Path: "run_as.user_name", Resource: "databricks_user", Match: "user_name"},
 			{Path: "new_cluster.aws_attributes.instance_profile_arn", Resource: "databricks_instance_profile"},
 			{Path: "new_cluster.init_scripts.dbfs.destination", Resource: "databricks_dbfs_file"},
 			{Path: "new_cluster.instance_pool_id", Resource: "databricks_instance_pool"},
 			{Path: "existing_cluster_id", Resource: "databricks_cluster"},
+			{Path: "job_cluster.new_cluster.aws_attributes.instance_profile_arn", Resource: "databricks_instance_profile"},
+			{Path: "job_cluster.new_cluster.init_scripts.dbfs.destination", Resource: "databricks_dbfs_file"},
+			{Path: "job_cluster.new_cluster.instance_pool_id", Resource: "databricks_instance_pool"},
 			{Path: "library.jar", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
 			{Path: "library.whl", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
 			{Path: "library.egg", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
 			{Path: "spark_python_task.python_file", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
 			{Path: "spark_python_task.parameters", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
 			{Path: "spark_jar_task.jar_uri", Resource: "databricks_dbfs_file", Match: "dbfs_path"},
+			{Path: "task.sql_task.query.query_id", Resource: "databricks_sql_query"},
+			{Path: "task.sql_task.dashboard.dashboard_id", Resource: "databricks_sql_dashboard"},
+			{Path: "task.sql_task.warehouse_id", Resource: "databricks_sql_endpoint"},
+			{Path: "task.dbt_task.warehouse_id", Resource: "databricks_sql_endpoint"},
 		},
 		Import: func(ic *importContext, r *resource) error {
 			var job jobs.JobSettings
@@ -208,10 +248,24 @@ var resourcesMap map[string]importable = map[string]importable{
 			if err := ic.importCluster(job.NewCluster); err != nil {
 				return err
 			}
+			for _, jc := range job.JobClusters {
+				if err := ic.importCluster(jc.NewCluster); err != nil {
+					return err
+				}
+			}
 			ic.Emit(&resource{
 				Resource: "databricks_cluster",
 				ID:       job.ExistingClusterID,
 			})
+			for _, task := range job.Tasks {
+				ic.importSqlTask(task.SqlTask)
+				if task.DbtTask != nil {
+					ic.Emit(&resource{
+						Resource: "databricks_sql_endpoint",
+						ID:       task.DbtTask.WarehouseID,
+					})
+				}
+			}
 			if ic.meAdmin {
 				ic.Emit(&resource{
 					Resource: "databricks_permissions",
@@ -258,7 +312,12 @@ var resourcesMap map[string]importable = map[string]importable{
 			if l, err := a.List(); err == nil {
 				i := 0
 				for _, job := range l.Jobs {
-					if !ic.MatchesName(job.Settings.Name) {
+					if !ic.isIncluded("jobs", job.Settings.Name) {
+						continue
+					}
+					if !ic.filters.matchesOwner(job.CreatorUserName) ||
+						!ic.filters.matchesJobTags(job.Settings) {
+						log.Printf("[INFO] Skipping filtered job %s", job.Settings.Name)
 						continue
 					}
 					if ic.lastActiveDays != 3650 {
@@ -343,7 +402,7 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for _, g := range ic.allGroups {
-				if !ic.MatchesName(g.DisplayName) {
+				if !ic.isIncluded("groups", g.DisplayName) {
 					continue
 				}
 				ic.Emit(&resource{
@@ -551,7 +610,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			ssAPI := access.NewSecretScopesAPI(ic.Context, ic.Client)
 			if scopes, err := ssAPI.List(); err == nil {
 				for i, scope := range scopes {
-					if !ic.MatchesName(scope.Name) {
+					if !ic.isIncluded("secrets", scope.Name) {
 						continue
 					}
 					ic.Emit(&resource{
@@ -617,7 +676,7 @@ var resourcesMap map[string]importable = map[string]importable{
 			{Path: "principal", Resource: "databricks_user", Match: "user_name"},
 		},
 	},
-	"databricks_aws_s3_mount": {
+	"databricks_mount": {
 		Service: "mounts",
 		List: func(ic *importContext) error {
 			if !ic.mounts {
@@ -627,70 +686,31 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for mountName, source := range ic.mountMap {
-				if !strings.HasPrefix(source.URL, "s3a://") {
+				gm, err := source.generic()
+				if err != nil {
+					log.Printf("[DEBUG] skipping %s: %v", mountName, err)
 					continue
 				}
-				if !ic.MatchesName(mountName) {
+				if !ic.isIncluded("mounts", mountName) {
 					continue
 				}
-				log.Printf("[INFO] Emitting databricks_aws_s3_mount: %s",
-					source.URL)
-				attrs := map[string]string{
-					"s3_bucket_name": strings.ReplaceAll(source.URL, "s3a://", ""),
-					"mount_name":     mountName,
-				}
 				if source.InstanceProfile != "" {
-					attrs["instance_profile"] = source.InstanceProfile
 					ic.Emit(&resource{
 						Resource: "databricks_instance_profile",
 						ID:       source.InstanceProfile,
 					})
-				} else if source.ClusterID != "" {
-					attrs["cluster_id"] = source.ClusterID
+				} else if source.ClusterID != "" && (gm.S3 != nil || gm.URI != "") {
+					// cluster is needed only for mounts with instance profiles
 					ic.Emit(&resource{
 						Resource: "databricks_cluster",
 						ID:       source.ClusterID,
 					})
 				}
+				log.Printf("[INFO] Emitting databricks_mount: %s", source.URL)
 				ic.Emit(&resource{
+					Resource: "databricks_mount",
 					ID:       mountName,
-					Resource: "databricks_aws_s3_mount",
-					Data: ic.Resources["databricks_aws_s3_mount"].Data(
-						&terraform.InstanceState{
-							ID:         mountName,
-							Attributes: attrs,
-						}),
-				})
-			}
-			return nil
-		},
-		Depends: []reference{
-			{Path: "s3_bucket_name", Resource: "aws_s3_bucket", Match: "bucket"},
-			{Path: "instance_profile", Resource: "databricks_instance_profile"},
-			{Path: "cluster_id", Resource: "databricks_cluster"},
-		},
-	},
-	"databricks_azure_adls_gen2_mount": {
-		Service: "mounts",
-		List: func(ic *importContext) error {
-			if !ic.mounts {
-				return nil
-			}
-			if err := ic.refreshMounts(); err != nil {
-				return err
-			}
-			for mountName, source := range ic.mountMap {
-				if res := adlsGen2Regex.FindStringSubmatch(source.URL); res == nil {
-					log.Printf("[DEBUG] skipping %s mounted at %s", source, mountName)
-					continue
-				}
-				if !ic.MatchesName(mountName) {
-					continue
-				}
-				ic.Emit(&resource{
-					Resource: "databricks_azure_adls_gen2_mount",
-					ID:       mountName,
-					Data: ic.Resources["databricks_azure_adls_gen2_mount"].Data(
+					Data: ic.Resources["databricks_mount"].Data(
 						&terraform.InstanceState{
 							ID: mountName,
 							// don't open another command/context
@@ -701,101 +721,95 @@ var resourcesMap map[string]importable = map[string]importable{
 			return nil
 		},
 		Body: func(ic *importContext, body *hclwrite.Body, r *resource) error {
-			b := body.AppendNewBlock("resource", []string{r.Resource, r.Name}).Body()
-
-			mount := ic.mountMap[r.ID]
-			res := adlsGen2Regex.FindStringSubmatch(mount.URL)
-			if res == nil {
-				return fmt.Errorf("can't extract ADLSv2 information from string '%s'", mount)
-			}
-			containerName := res[2]
-			storageAccountName := res[3]
-			b.SetAttributeValue("container_name", cty.StringVal(containerName))
-			b.SetAttributeValue("storage_account_name", cty.StringVal(storageAccountName))
-			if res[4] != "" && res[4] != "/" {
-				b.SetAttributeValue("directory", cty.StringVal(res[4]))
-			}
-			b.SetAttributeValue("mount_name", cty.StringVal(strings.Replace(r.ID, "/mnt/", "", 1)))
-
-			varName := "_" + storageAccountName + "_" + containerName
-			textStr := fmt.Sprintf(" for mounting ADLSv2 resource %s://%s@%s",
-				res[1], containerName, storageAccountName)
-
-			b.SetAttributeRaw("client_id", ic.variable(
-				"client_id"+varName, "Client ID"+textStr))
-			b.SetAttributeRaw("tenant_id", ic.variable(
-				"tenant_id"+varName, "Tenant ID"+textStr))
-			b.SetAttributeRaw("client_secret_scope", ic.variable(
-				"client_secret_scope"+varName,
-				"Secret scope name that stores app client secret"+textStr))
-			b.SetAttributeRaw("client_secret_key", ic.variable(
-				"client_secret_key"+varName,
-				"Key in secret scope that stores app client secret"+textStr))
-
-			return nil
-		},
-		Depends: []reference{
-			{Path: "storage_account_name", Resource: "azurerm_storage_account", Match: "name"},
-			{Path: "container_name", Resource: "azurerm_storage_container", Match: "name"},
-		},
-	},
-	"databricks_azure_adls_gen1_mount": {
-		Service: "mounts",
-		List: func(ic *importContext) error {
-			if !ic.mounts {
-				return nil
-			}
-			if err := ic.refreshMounts(); err != nil {
+			source := ic.mountMap[r.ID]
+			gm, err := source.generic()
+			if err != nil {
 				return err
 			}
-			for mountName, source := range ic.mountMap {
-				if res := adlsGen1Regex.FindStringSubmatch(source.URL); res == nil {
-					continue
+			i := ic.Importables[r.Resource]
+			b := body.AppendNewBlock("resource", []string{r.Resource, r.Name}).Body()
+			b.SetAttributeValue("name", cty.StringVal(r.ID))
+			setDirectory := func(b *hclwrite.Body, dir string) {
+				if dir != "" && dir != "/" {
+					b.SetAttributeValue("directory", cty.StringVal(dir))
 				}
-				if !ic.MatchesName(mountName) {
-					continue
+			}
+			switch {
+			case gm.S3 != nil:
+				s3 := b.AppendNewBlock("s3", []string{}).Body()
+				s3.SetAttributeRaw("bucket_name", ic.reference(i,
+					[]string{"s3", "bucket_name"}, gm.S3.BucketName))
+				if source.InstanceProfile != "" {
+					s3.SetAttributeRaw("instance_profile", ic.reference(i,
+						[]string{"s3", "instance_profile"}, source.InstanceProfile))
+				} else if source.ClusterID != "" {
+					b.SetAttributeRaw("cluster_id", ic.reference(i,
+						[]string{"cluster_id"}, source.ClusterID))
+				}
+			case gm.Abfs != nil:
+				abfs := b.AppendNewBlock("abfs", []string{}).Body()
+				abfs.SetAttributeRaw("container_name", ic.reference(i,
+					[]string{"abfs", "container_name"}, gm.Abfs.ContainerName))
+				abfs.SetAttributeRaw("storage_account_name", ic.reference(i,
+					[]string{"abfs", "storage_account_name"}, gm.Abfs.StorageAccountName))
+				setDirectory(abfs, gm.Abfs.Directory)
+				abfs.SetAttributeValue("initialize_file_system", cty.False)
+				varName := "_" + gm.Abfs.StorageAccountName + "_" + gm.Abfs.ContainerName
+				textStr := fmt.Sprintf(" for mounting ADLSv2 resource %s", source.URL)
+				abfs.SetAttributeRaw("client_id", ic.variable("client_id"+varName, "Client ID"+textStr))
+				abfs.SetAttributeRaw("tenant_id", ic.variable("tenant_id"+varName, "Tenant ID"+textStr))
+				ic.mountSecret(abfs, "client_secret_scope", "client_secret_key", varName,
+					"app client secret"+textStr)
+			case gm.Adl != nil:
+				adl := b.AppendNewBlock("adl", []string{}).Body()
+				adl.SetAttributeRaw("storage_resource_name", ic.reference(i,
+					[]string{"adl", "storage_resource_name"}, gm.Adl.StorageResource))
+				setDirectory(adl, gm.Adl.Directory)
+				varName := "_" + gm.Adl.StorageResource
+				textStr := fmt.Sprintf(" for mounting ADLSv1 resource %s", source.URL)
+				adl.SetAttributeRaw("client_id", ic.variable("client_id"+varName, "Client ID"+textStr))
+				adl.SetAttributeRaw("tenant_id", ic.variable("tenant_id"+varName, "Tenant ID"+textStr))
+				ic.mountSecret(adl, "client_secret_scope", "client_secret_key", varName,
+					"app client secret"+textStr)
+			case gm.Wasb != nil:
+				wasb := b.AppendNewBlock("wasb", []string{}).Body()
+				wasb.SetAttributeRaw("container_name", ic.reference(i,
+					[]string{"wasb", "container_name"}, gm.Wasb.ContainerName))
+				wasb.SetAttributeRaw("storage_account_name", ic.reference(i,
+					[]string{"wasb", "storage_account_name"}, gm.Wasb.StorageAccountName))
+				setDirectory(wasb, gm.Wasb.Directory)
+				varName := "_" + gm.Wasb.StorageAccountName + "_" + gm.Wasb.ContainerName
+				textStr := fmt.Sprintf(" for mounting Azure Blob Storage resource %s", source.URL)
+				wasb.SetAttributeRaw("auth_type", ic.variable("auth_type"+varName,
+					"Either SAS or ACCESS_KEY"+textStr))
+				ic.mountSecret(wasb, "token_secret_scope", "token_secret_key", varName,
+					"SAS token or access key"+textStr)
+			case gm.Gs != nil:
+				gs := b.AppendNewBlock("gs", []string{}).Body()
+				gs.SetAttributeRaw("bucket_name", ic.reference(i,
+					[]string{"gs", "bucket_name"}, gm.Gs.BucketName))
+				gs.SetAttributeRaw("service_account", ic.variable(
+					"service_account_"+gm.Gs.BucketName,
+					"Google service account for mounting GCS bucket "+gm.Gs.BucketName))
+			default:
+				b.SetAttributeValue("uri", cty.StringVal(gm.URI))
+				if source.ClusterID != "" {
+					b.SetAttributeRaw("cluster_id", ic.reference(i,
+						[]string{"cluster_id"}, source.ClusterID))
 				}
-				ic.Emit(&resource{
-					Resource: "databricks_azure_adls_gen1_mount",
-					ID:       mountName,
-					Data: ic.Resources["databricks_azure_adls_gen2_mount"].Data(
-						&terraform.InstanceState{
-							ID: mountName,
-							// don't open another command/context
-							Attributes: map[string]string{},
-						}),
-				})
 			}
 			return nil
 		},
-		Body: func(ic *importContext, body *hclwrite.Body, r *resource) error {
-			b := body.AppendNewBlock("resource", []string{r.Resource, r.Name}).Body()
-
-			mount := ic.mountMap[r.ID]
-			res := adlsGen1Regex.FindStringSubmatch(mount.URL)
-			if res == nil {
-				return fmt.Errorf("can't extract ADLSv1 information from string '%s'", mount)
-			}
-			storageResourceName := res[2]
-			b.SetAttributeValue("storage_resource_name", cty.StringVal(storageResourceName))
-			if res[3] != "" && res[3] != "/" {
-				b.SetAttributeValue("directory", cty.StringVal(res[3]))
-			}
-			b.SetAttributeValue("mount_name", cty.StringVal(strings.Replace(r.ID, "/mnt/", "", 1)))
-			varName := "_" + storageResourceName
-			textStr := fmt.Sprintf(" for mounting ADLSv1 resource %s://%s", res[1], storageResourceName)
-
-			b.SetAttributeRaw("client_id", ic.variable("client_id"+varName, "Client ID"+textStr))
-			b.SetAttributeRaw("tenant_id", ic.variable("tenant_id"+varName, "Tenant IDs"+textStr))
-			b.SetAttributeRaw("client_secret_scope", ic.variable(
-				"client_secret_scope"+varName, "Secret scope name that stores app client secret"+textStr))
-			b.SetAttributeRaw("client_secret_key", ic.variable(
-				"client_secret_key"+varName, "Key in secret scope that stores app client secret"+textStr))
-
-			return nil
-		},
 		Depends: []reference{
-			{Path: "storage_resource_name", Resource: "azurerm_data_lake_store", Match: "name"},
+			{Path: "s3.bucket_name", Resource: "aws_s3_bucket", Match: "bucket"},
+			{Path: "s3.instance_profile", Resource: "databricks_instance_profile"},
+			{Path: "cluster_id", Resource: "databricks_cluster"},
+			{Path: "abfs.storage_account_name", Resource: "azurerm_storage_account", Match: "name"},
+			{Path: "abfs.container_name", Resource: "azurerm_storage_container", Match: "name"},
+			{Path: "adl.storage_resource_name", Resource: "azurerm_data_lake_store", Match: "name"},
+			{Path: "wasb.storage_account_name", Resource: "azurerm_storage_account", Match: "name"},
+			{Path: "wasb.container_name", Resource: "azurerm_storage_container", Match: "name"},
+			{Path: "gs.bucket_name", Resource: "google_storage_bucket", Match: "name"},
 		},
 	},
 	"databricks_global_init_script": {
@@ -814,6 +828,9 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for offset, gis := range globalInitScripts {
+				if !ic.isIncluded("workspace", gis.Name) {
+					continue
+				}
 				ic.Emit(&resource{
 					Resource: "databricks_global_init_script",
 					ID:       gis.ScriptID,
@@ -875,6 +892,9 @@ var resourcesMap map[string]importable = map[string]importable{
 				return err
 			}
 			for offset, repo := range repoList {
+				if !ic.isIncluded("repos", repo.Path) || !ic.filters.matchesPath(repo.Path) {
+					continue
+				}
 				if repo.Url != "" {
 					ic.Emit(&resource{
 						Resource: "databricks_repo",
@@ -910,4 +930,25 @@ var resourcesMap map[string]importable = map[string]importable{
 			return nil
 		},
 	},
+	"databricks_sql_endpoint": {
+		Service: "sql",
+		Name: func(d *schema.ResourceData) string {
+			return d.Get("name").(string)
+		},
+	},
+	"databricks_sql_query": {
+		Service: "sql",
+		Name: func(d *schema.ResourceData) string {
+			return d.Get("name").(string)
+		},
+		Depends: []reference{
+			{Path: "data_source_id", Resource: "databricks_sql_endpoint", Match: "data_source_id"},
+		},
+	},
+	"databricks_sql_dashboard": {
+		Service: "sql",
+		Name: func(d *schema.ResourceData) string {
+			return d.Get("name").(string)
+		},
+	},
 }
@@ -0,0 +1,276 @@
+package exporter
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/pools"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// dataSourcesService marks data sources generated in portable mode, which values
+// describe the original workspace and are written as is
+const dataSourcesService = "data"
+
+var (
+	portableNameRegex   = regexp.MustCompile(`[^0-9a-z_]`)
+	scalaVersionRegex   = regexp.MustCompile(`-scala([\d\.]+)$`)
+	apacheSparkVerRegex = regexp.MustCompile(`Apache Spark ([\d\.]+)`)
+)
+
+// portableName makes HCL identifier out of workspace-specific value
+func (ic *importContext) portableName(prefix, value string) string {
+	name := portableNameRegex.ReplaceAllString(strings.ToLower(value), "_")
+	return ic.prefix + prefix + strings.Trim(name, "_")
+}
+
+// isPortableNodeType tells if computed node type has to be written out
+// in portable mode, which happens only when it's not coming from a pool
+func (ic *importContext) isPortableNodeType(path []string, a string, d *schema.ResourceData) bool {
+	if !ic.portable || (a != "node_type_id" && a != "driver_node_type_id") {
+		return false
+	}
+	prefix := strings.Join(path, ".")
+	if prefix != "" {
+		prefix += "."
+	}
+	get := func(k string) string {
+		v, _ := d.Get(prefix + k).(string)
+		return v
+	}
+	if get("instance_pool_id") != "" {
+		return false
+	}
+	if a == "driver_node_type_id" {
+		return get("driver_instance_pool_id") == "" &&
+			get("driver_node_type_id") != get("node_type_id")
+	}
+	return true
+}
+
+// portableValue replaces workspace-specific value with either variable or data source
+// reference. Returns nil if value has to be written as is.
+func (ic *importContext) portableValue(i importable, path []string, value string) hclwrite.Tokens {
+	if i.Service == dataSourcesService || len(path) == 0 || value == "" {
+		return nil
+	}
+	if strings.HasPrefix(value, "dbfs:/") {
+		return ic.portableDbfsPath(value)
+	}
+	switch path[len(path)-1] {
+	case "node_type_id", "driver_node_type_id":
+		return ic.portableNodeType(value)
+	case "spark_version", "preloaded_spark_versions":
+		return ic.portableSparkVersion(value)
+	case "instance_profile_arn", "instance_profile_id", "instance_profile":
+		return ic.portableInstanceProfile(value)
+	case "instance_pool_id", "driver_instance_pool_id":
+		return ic.portableInstancePool(value)
+	}
+	return nil
+}
+
+func (ic *importContext) cacheNodeTypes() error {
+	if ic.nodeTypes != nil {
+		return nil
+	}
+	log.Printf("[INFO] Caching node types in memory ...")
+	nodeTypes, err := clusters.NewClustersAPI(ic.Context, ic.Client).ListNodeTypes()
+	if err != nil {
+		return err
+	}
+	ic.nodeTypes = &nodeTypes
+	log.Printf("[INFO] Cached %d node types", len(nodeTypes.NodeTypes))
+	return nil
+}
+
+func (ic *importContext) cacheSparkVersions() error {
+	if ic.sparkVersions != nil {
+		return nil
+	}
+	log.Printf("[INFO] Caching spark versions in memory ...")
+	sparkVersions, err := clusters.NewClustersAPI(ic.Context, ic.Client).ListSparkVersions()
+	if err != nil {
+		return err
+	}
+	ic.sparkVersions = &sparkVersions
+	log.Printf("[INFO] Cached %d spark versions", len(sparkVersions.SparkVersions))
+	return nil
+}
+
+// portableNodeType returns reference to databricks_node_type data source,
+// that selects node type with characteristics of the original one
+func (ic *importContext) portableNodeType(nodeTypeID string) hclwrite.Tokens {
+	if err := ic.cacheNodeTypes(); err != nil {
+		log.Printf("[WARN] Cannot list node types: %v", err)
+		return nil
+	}
+	for _, nt := range ic.nodeTypes.NodeTypes {
+		if nt.NodeTypeID != nodeTypeID {
+			continue
+		}
+		req := clusters.NodeTypeRequest{
+			MinMemoryGB:           nt.MemoryMB / 1024,
+			MinCores:              int32(nt.NumCores),
+			MinGPUs:               nt.NumGPUs,
+			Category:              nt.Category,
+			PhotonWorkerCapable:   nt.PhotonWorkerCapable,
+			PhotonDriverCapable:   nt.PhotonDriverCapable,
+			IsIOCacheEnabled:      nt.IsIOCacheEnabled,
+			SupportPortForwarding: nt.SupportPortForwarding,
+			Graviton:              clusters.IsGravitonNodeType(nt),
+		}
+		if nt.NodeInstanceType != nil {
+			req.LocalDisk = nt.NodeInstanceType.LocalDisks > 0 ||
+				nt.NodeInstanceType.LocalNVMeDisks > 0
+		}
+		return ic.portableDataSource("databricks_node_type",
+			ic.portableName("", nodeTypeID), req)
+	}
+	log.Printf("[WARN] Cannot find node type %s", nodeTypeID)
+	return nil
+}
+
+// portableSparkVersion returns reference to databricks_spark_version data source,
+// that selects runtime with the same flavor and Apache Spark version as the original one
+func (ic *importContext) portableSparkVersion(version string) hclwrite.Tokens {
+	if err := ic.cacheSparkVersions(); err != nil {
+		log.Printf("[WARN] Cannot list spark versions: %v", err)
+		return nil
+	}
+	for _, sv := range ic.sparkVersions.SparkVersions {
+		if sv.Version != version {
+			continue
+		}
+		req := clusters.SparkVersionRequest{
+			Latest: true,
+			LongTermSupport: strings.Contains(sv.Description, "LTS") ||
+				strings.Contains(sv.Version, "-esr-"),
+			Beta:     strings.Contains(sv.Description, "Beta"),
+			ML:       strings.Contains(sv.Version, "-ml-"),
+			Genomics: strings.Contains(sv.Version, "-hls-"),
+			GPU:      strings.Contains(sv.Version, "-gpu-"),
+			Photon:   strings.Contains(sv.Version, "-photon-"),
+			AArch64:  strings.Contains(sv.Version, "-aarch64-"),
+		}
+		if m := scalaVersionRegex.FindStringSubmatch(sv.Version); m != nil {
+			req.Scala = m[1]
+		}
+		if m := apacheSparkVerRegex.FindStringSubmatch(sv.Description); m != nil {
+			req.SparkVersion = m[1]
+		}
+		return ic.portableDataSource("databricks_spark_version",
+			ic.portableName("dbr_", version), req)
+	}
+	log.Printf("[WARN] Cannot find spark version %s", version)
+	return nil
+}
+
+// portableInstanceProfile lifts instance profile ARN into a variable
+func (ic *importContext) portableInstanceProfile(arn string) hclwrite.Tokens {
+	if !strings.HasPrefix(arn, "arn:") {
+		return nil
+	}
+	split := strings.Split(arn, "/")
+	profile := split[len(split)-1]
+	return ic.portableVariable(ic.portableName("instance_profile_", profile),
+		fmt.Sprintf("Instance profile ARN for %s", profile), arn)
+}
+
+// portableInstancePool lifts ID of instance pool, that is not exported, into a variable
+// named after the pool
+func (ic *importContext) portableInstancePool(instancePoolID string) hclwrite.Tokens {
+	name := instancePoolID
+	pool, err := pools.NewInstancePoolsAPI(ic.Context, ic.Client).Read(instancePoolID)
+	if err != nil {
+		log.Printf("[WARN] Cannot read instance pool %s: %v", instancePoolID, err)
+	} else {
+		name = pool.InstancePoolName
+	}
+	return ic.portableVariable(ic.portableName("instance_pool_", name),
+		fmt.Sprintf("Instance pool ID for %s", name), instancePoolID)
+}
+
+// portableDbfsPath lifts DBFS path, that is not exported as databricks_dbfs_file, into a variable
+func (ic *importContext) portableDbfsPath(dbfsPath string) hclwrite.Tokens {
+	return ic.portableVariable(ic.portableName("dbfs_", strings.TrimPrefix(dbfsPath, "dbfs:/")),
+		fmt.Sprintf("DBFS path for %s", dbfsPath), dbfsPath)
+}
+
+// portableVariable creates variable with example value for terraform.tfvars.example
+func (ic *importContext) portableVariable(name, desc, value string) hclwrite.Tokens {
+	// values are looked up by the same name, that variable gets
+	name = variableNameRegex.ReplaceAllString(name, "_")
+	ic.variableValues[name] = value
+	return ic.variable(name, desc)
+}
+
+// portableDataSource writes data source block to data.tf only once and returns reference to it
+func (ic *importContext) portableDataSource(dataType, name string, req interface{}) hclwrite.Tokens {
+	key := fmt.Sprintf("%s.%s", dataType, name)
+	if !ic.portableData[key] {
+		ds := ic.DataSources[dataType]
+		d := ds.Data(&terraform.InstanceState{
+			Attributes: map[string]string{},
+		})
+		d.MarkNewResource()
+		if err := common.StructToData(req, ds.Schema, d); err != nil {
+			log.Printf("[ERROR] Cannot generate %s: %v", key, err)
+			return nil
+		}
+		f, ok := ic.Files["data"]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			ic.Files["data"] = f
+		}
+		block := f.Body().AppendNewBlock("data", []string{dataType, name})
+		if err := ic.dataToHcl(importable{Service: dataSourcesService},
+			[]string{}, ds, d, block.Body()); err != nil {
+			log.Printf("[ERROR] Cannot generate %s: %v", key, err)
+			return nil
+		}
+		ic.portableData[key] = true
+	}
+	return hclwrite.TokensForTraversal(hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: dataType},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+// writeTfvarsExample writes all variables with values from the source workspace,
+// so that it's easy to fill them in for the target environment. Secrets and other
+// values, that are not known, get a placeholder with the description of variable.
+func (ic *importContext) writeTfvarsExample() error {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	for _, k := range ic.variableNames() {
+		value, ok := ic.variableValues[k]
+		if !ok {
+			value = fmt.Sprintf("<%s>", ic.variables[k])
+		}
+		body.SetAttributeValue(k, cty.StringVal(value))
+	}
+	generatedFile := fmt.Sprintf("%s/terraform.tfvars.example", ic.Directory)
+	tf, err := os.Create(generatedFile)
+	if err != nil {
+		return err
+	}
+	defer tf.Close()
+	if _, err = tf.Write(hclwrite.Format(f.Bytes())); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Created %s", generatedFile)
+	return nil
+}
@@ -0,0 +1,252 @@
+package exporter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const providerAddress = `provider["registry.terraform.io/databrickslabs/databricks"]`
+
+type stateInstance struct {
+	SchemaVersion       int             `json:"schema_version"`
+	Attributes          json.RawMessage `json:"attributes"`
+	SensitiveAttributes []interface{}   `json:"sensitive_attributes"`
+	Dependencies        []string        `json:"dependencies,omitempty"`
+}
+
+type stateResource struct {
+	Module    string          `json:"module,omitempty"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Provider  string          `json:"provider"`
+	Instances []stateInstance `json:"instances"`
+}
+
+// stateV4 is the subset of Terraform state file format, that is enough
+// to run terraform plan right after export without running import.sh
+type stateV4 struct {
+	Version          int                    `json:"version"`
+	TerraformVersion string                 `json:"terraform_version"`
+	Serial           int64                  `json:"serial"`
+	Lineage          string                 `json:"lineage"`
+	Outputs          map[string]interface{} `json:"outputs"`
+	Resources        []stateResource        `json:"resources"`
+}
+
+// addToState converts resource data into state entry and finds dependencies
+// from the references in generated HCL. Returns false, if resource has to be
+// imported with import.sh instead.
+func (ic *importContext) addToState(r *resource, generated []byte) (bool, error) {
+	pr, ok := ic.Resources[r.Resource]
+	if !ok {
+		return false, fmt.Errorf("%s is not available in provider", r.Resource)
+	}
+	ty := pr.CoreConfigSchema().ImpliedType()
+	state := r.Data.State()
+	if state == nil {
+		return false, fmt.Errorf("%s has no state", r)
+	}
+	if missing := incompleteAttributes(pr, state); missing != "" {
+		// refresh of such state entry would fail or plan a replacement,
+		// so resource has to be imported with import.sh instead
+		log.Printf("[WARN] %s is not added to state, because %s. Run import.sh to import it", r, missing)
+		return false, nil
+	}
+	val, err := state.AttrsAsObjectValue(ty)
+	if err != nil {
+		return false, err
+	}
+	attributes, err := ctyjson.Marshal(val, ty)
+	if err != nil {
+		return false, err
+	}
+	dependencies, err := ic.hclDependencies(generated)
+	if err != nil {
+		return false, err
+	}
+	module := ""
+	if ic.Module != "" {
+		module = "module." + ic.Module
+	}
+	ic.stateResources = append(ic.stateResources, stateResource{
+		Module:   module,
+		Mode:     "managed",
+		Type:     r.Resource,
+		Name:     r.Name,
+		Provider: providerAddress,
+		Instances: []stateInstance{
+			{
+				SchemaVersion:       pr.SchemaVersion,
+				Attributes:          attributes,
+				SensitiveAttributes: []interface{}{},
+				Dependencies:        dependencies,
+			},
+		},
+	})
+	return true, nil
+}
+
+// incompleteAttributes explains, why resource data is not enough for the state entry
+func incompleteAttributes(pr *schema.Resource, state *terraform.InstanceState) string {
+	hasAttributes := false
+	for k := range state.Attributes {
+		if k != "id" {
+			hasAttributes = true
+			break
+		}
+	}
+	if !hasAttributes {
+		return "only its ID is known"
+	}
+	missing := []string{}
+	for k, s := range pr.Schema {
+		if !s.Required {
+			continue
+		}
+		if _, ok := state.Attributes[k]; ok {
+			continue
+		}
+		if v, ok := state.Attributes[k+".#"]; ok && v != "0" {
+			continue
+		}
+		missing = append(missing, k)
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	return fmt.Sprintf("required %s not known", strings.Join(missing, ", "))
+}
+
+// hclDependencies returns sorted addresses of all resources, that are referenced in HCL
+func (ic *importContext) hclDependencies(generated []byte) ([]string, error) {
+	file, diags := hclsyntax.ParseConfig(generated, "generated.tf", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	found := map[string]bool{}
+	var walk func(body *hclsyntax.Body)
+	walk = func(body *hclsyntax.Body) {
+		for _, attr := range body.Attributes {
+			for _, traversal := range attr.Expr.Variables() {
+				if len(traversal) < 2 {
+					continue
+				}
+				if _, ok := ic.Resources[traversal.RootName()]; !ok {
+					continue
+				}
+				name, ok := traversal[1].(hcl.TraverseAttr)
+				if !ok {
+					continue
+				}
+				address := fmt.Sprintf("%s.%s", traversal.RootName(), name.Name)
+				if ic.Module != "" {
+					address = fmt.Sprintf("module.%s.%s", ic.Module, address)
+				}
+				found[address] = true
+			}
+		}
+		for _, block := range body.Blocks {
+			walk(block.Body)
+		}
+	}
+	walk(file.Body.(*hclsyntax.Body))
+	dependencies := []string{}
+	for address := range found {
+		dependencies = append(dependencies, address)
+	}
+	sort.Strings(dependencies)
+	return dependencies, nil
+}
+
+func newLineage() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// random UUID v4, just like Terraform does
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+var terraformVersionRegex = regexp.MustCompile(`Terraform v(\d+\.\d+\.\d+\S*)`)
+
+// parseTerraformVersion reads version from either JSON or human-readable output of terraform version
+func parseTerraformVersion(out []byte) (string, error) {
+	var version struct {
+		TerraformVersion string `json:"terraform_version"`
+	}
+	if err := json.Unmarshal(out, &version); err == nil && version.TerraformVersion != "" {
+		return version.TerraformVersion, nil
+	}
+	if match := terraformVersionRegex.FindSubmatch(out); match != nil {
+		return string(match[1]), nil
+	}
+	return "", fmt.Errorf("cannot parse terraform version: %s", out)
+}
+
+// terraformVersion returns version of local terraform binary, that is going to read the state
+func (ic *importContext) terraformVersion() (string, error) {
+	cmd := exec.CommandContext(context.Background(), "terraform", "version", "-json")
+	cmd.Dir = ic.Directory
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot get terraform version: %w", err)
+	}
+	return parseTerraformVersion(out)
+}
+
+// writeState writes terraform.tfstate, unless it's already present in the directory
+func (ic *importContext) writeState() error {
+	generatedFile := fmt.Sprintf("%s/terraform.tfstate", ic.Directory)
+	if _, err := os.Stat(generatedFile); err == nil {
+		return fmt.Errorf("%s already exists", generatedFile)
+	}
+	terraformVersion, err := ic.terraformVersion()
+	if err != nil {
+		return err
+	}
+	lineage, err := newLineage()
+	if err != nil {
+		return err
+	}
+	sort.Slice(ic.stateResources, func(i, j int) bool {
+		a, b := ic.stateResources[i], ic.stateResources[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+	raw, err := json.MarshalIndent(stateV4{
+		Version:          4,
+		TerraformVersion: terraformVersion,
+		Serial:           1,
+		Lineage:          lineage,
+		Outputs:          map[string]interface{}{},
+		Resources:        ic.stateResources,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(generatedFile, raw, 0600); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Written %d resources to %s", len(ic.stateResources), generatedFile)
+	return nil
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/identity"
+	"github.com/databrickslabs/terraform-provider-databricks/jobs"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
 	"github.com/databrickslabs/terraform-provider-databricks/storage"
 
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -48,6 +50,28 @@ func (ic *importContext) importCluster(c *clusters.Cluster) error {
 	return nil
 }
 
+func (ic *importContext) importSqlTask(t *jobs.SqlTask) {
+	if t == nil {
+		return
+	}
+	if t.Query != nil {
+		ic.Emit(&resource{
+			Resource: "databricks_sql_query",
+			ID:       t.Query.QueryID,
+		})
+	}
+	if t.Dashboard != nil {
+		ic.Emit(&resource{
+			Resource: "databricks_sql_dashboard",
+			ID:       t.Dashboard.DashboardID,
+		})
+	}
+	ic.Emit(&resource{
+		Resource: "databricks_sql_endpoint",
+		ID:       t.WarehouseID,
+	})
+}
+
 func (ic *importContext) importLibraries(d *schema.ResourceData, s map[string]*schema.Schema) error {
 	var cll libraries.ClusterLibraryList
 	err := common.DataToStructPointer(d, s, &cll)
@@ -186,6 +210,67 @@ func (ic *importContext) refreshMounts() error {
 	return nil
 }
 
+// generic converts source of a mount into configuration of databricks_mount
+func (m mount) generic() (gm storage.GenericMount, err error) {
+	if res := s3Regex.FindStringSubmatch(m.URL); res != nil {
+		if res[3] != "" && res[3] != "/" {
+			// s3 block has no directory, so it's mounted by URI
+			gm.URI = m.URL
+			return
+		}
+		gm.S3 = &storage.S3IamMount{
+			BucketName:      res[2],
+			InstanceProfile: m.InstanceProfile,
+		}
+		return
+	}
+	if res := adlsGen2Regex.FindStringSubmatch(m.URL); res != nil {
+		gm.Abfs = &storage.AzureADLSGen2MountGeneric{
+			ContainerName:      res[2],
+			StorageAccountName: res[3],
+			Directory:          res[4],
+		}
+		return
+	}
+	if res := adlsGen1Regex.FindStringSubmatch(m.URL); res != nil {
+		gm.Adl = &storage.AzureADLSGen1MountGeneric{
+			StorageResource: res[2],
+			Directory:       res[3],
+		}
+		return
+	}
+	if res := wasbRegex.FindStringSubmatch(m.URL); res != nil {
+		gm.Wasb = &storage.AzureBlobMountGeneric{
+			ContainerName:      res[2],
+			StorageAccountName: res[3],
+			Directory:          res[4],
+		}
+		return
+	}
+	if res := gsRegex.FindStringSubmatch(m.URL); res != nil {
+		if res[3] != "" && res[3] != "/" {
+			// gs block has no directory, so it's mounted by URI
+			gm.URI = m.URL
+			return
+		}
+		gm.Gs = &storage.GSMount{
+			BucketName: res[2],
+		}
+		return
+	}
+	err = fmt.Errorf("unsupported mount source: %s", m.URL)
+	return
+}
+
+// mountSecret writes secret scope and key attributes of a mount as variables. Listing of mounts
+// returns only the source URL, so it's not known, which of exported secrets the mount uses.
+func (ic *importContext) mountSecret(b *hclwrite.Body, scopeAttr, keyAttr, varName, desc string) {
+	b.SetAttributeRaw(scopeAttr, ic.variable(scopeAttr+varName,
+		"Secret scope name that stores "+desc))
+	b.SetAttributeRaw(keyAttr, ic.variable(keyAttr+varName,
+		"Key in secret scope that stores "+desc))
+}
+
 var getReadableMountsCommand = `
 import scala.concurrent._
 import scala.concurrent.duration._
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceCronPreview returns the next fire times of job schedule, so that they are visible in the plan
+func DataSourceCronPreview() *schema.Resource {
+	type cronPreview struct {
+		QuartzCronExpression string   `json:"quartz_cron_expression"`
+		TimezoneID           string   `json:"timezone_id,omitempty" tf:"default:UTC"`
+		StartTime            string   `json:"start_time,omitempty"`
+		Limit                int      `json:"limit,omitempty" tf:"default:5"`
+		NextRuns             []string `json:"next_runs,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(cronPreview{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["quartz_cron_expression"].ValidateFunc = validateQuartzCron
+		s["timezone_id"].ValidateFunc = validateTimezoneID
+		s["start_time"].ValidateFunc = validation.IsRFC3339Time
+		s["limit"].ValidateFunc = validation.IntBetween(1, 100)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this cronPreview
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			cron, err := parseQuartzCron(this.QuartzCronExpression)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			loc, err := time.LoadLocation(this.TimezoneID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			start := time.Now()
+			if this.StartTime != "" {
+				start, err = time.Parse(time.RFC3339, this.StartTime)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			this.NextRuns = []string{}
+			for _, run := range cron.NextRuns(start, loc, this.Limit) {
+				this.NextRuns = append(this.NextRuns, run.Format(time.RFC3339))
+			}
+			d.SetId(this.QuartzCronExpression)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronPreviewDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Resource:    DataSourceCronPreview(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		quartz_cron_expression = "0 0 6 ? * MON"
+		timezone_id = "America/New_York"
+		start_time = "2021-10-06T00:00:00Z"
+		limit = 2`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "0 0 6 ? * MON", d.Id())
+	assert.Equal(t, []interface{}{
+		"2021-10-11T06:00:00-04:00",
+		"2021-10-18T06:00:00-04:00",
+	}, d.Get("next_runs"))
+}
+
+func TestCronPreviewDataSource_InvalidExpression(t *testing.T) {
+	qa.ResourceFixture{
+		Resource:    DataSourceCronPreview(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `quartz_cron_expression = "0 0 6 * * MON"`,
+	}.ExpectError(t, "invalid config supplied. [quartz_cron_expression] invalid value for "+
+		"quartz_cron_expression (exactly one of day-of-month and day-of-week must be ?)")
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// findJobByName returns the only job with exactly the given name
+func (a JobsAPI) findJobByName(name string) (*Job, error) {
+	list, err := a.List()
+	if err != nil {
+		return nil, err
+	}
+	var found *Job
+	for _, job := range list.Jobs {
+		if job.Settings == nil || job.Settings.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("there is more than one job with name '%s': %d and %d",
+				name, found.JobID, job.JobID)
+		}
+		j := job
+		found = &j
+	}
+	if found == nil {
+		return nil, fmt.Errorf("there is no job with name '%s'", name)
+	}
+	return found, nil
+}
+
+// DataSourceJob returns settings of the job specified by ID or unique name
+func DataSourceJob() *schema.Resource {
+	type jobData struct {
+		JobID       string       `json:"job_id,omitempty" tf:"computed"`
+		JobName     string       `json:"job_name,omitempty" tf:"computed"`
+		JobSettings *JobSettings `json:"job_settings,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(jobData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["job_id"].ExactlyOneOf = []string{"job_id", "job_name"}
+		s["job_name"].ExactlyOneOf = []string{"job_id", "job_name"}
+		common.ComputedSchema(s["job_settings"].Elem.(*schema.Resource).Schema)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this jobData
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// tasks are returned only by Jobs API 2.1
+			jobsAPI := NewJobsAPI(context.WithValue(ctx, common.Api, common.API_2_1), m)
+			if this.JobID == "" {
+				found, err := jobsAPI.findJobByName(this.JobName)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				this.JobID = found.ID()
+			}
+			job, err := jobsAPI.Read(this.JobID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.JobSettings = job.Settings
+			if job.Settings != nil {
+				this.JobName = job.Settings.Name
+			}
+			d.SetId(this.JobID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobsListFixtures = []qa.HTTPFixture{
+	{
+		Method:       "GET",
+		Resource:     "/api/2.1/jobs/list?limit=25",
+		ReuseRequest: true,
+		Response: JobList{
+			Jobs: []Job{
+				{
+					JobID: 1,
+					Settings: &JobSettings{
+						Name: "Ingest",
+					},
+				},
+				{
+					JobID: 2,
+					Settings: &JobSettings{
+						Name: "Report",
+					},
+				},
+			},
+			HasMore: true,
+		},
+	},
+	{
+		Method:       "GET",
+		Resource:     "/api/2.1/jobs/list?limit=25&offset=2",
+		ReuseRequest: true,
+		Response: JobList{
+			Jobs: []Job{
+				{
+					JobID: 3,
+					Settings: &JobSettings{
+						Name: "Report",
+					},
+				},
+				{
+					JobID: 4,
+					Settings: &JobSettings{
+						Name: "Cleanup",
+					},
+				},
+			},
+		},
+	},
+}
+
+var ingestJobFixture = qa.HTTPFixture{
+	Method:   "GET",
+	Resource: "/api/2.1/jobs/get?job_id=1",
+	Response: Job{
+		JobID: 1,
+		Settings: &JobSettings{
+			Name: "Ingest",
+			Tasks: []JobTaskSettings{
+				{
+					TaskKey:           "a",
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Ingest",
+					},
+				},
+			},
+			MaxConcurrentRuns: 1,
+		},
+	},
+}
+
+func TestJobDataSource_ByID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{ingestJobFixture},
+		Resource:    DataSourceJob(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `job_id = "1"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, "Ingest", d.Get("job_name"))
+	assert.Equal(t, "/Ingest", d.Get("job_settings.0.task.0.notebook_task.0.notebook_path"))
+}
+
+func TestJobDataSource_ByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    append(jobsListFixtures, ingestJobFixture),
+		Resource:    DataSourceJob(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `job_name = "Ingest"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, "1", d.Get("job_id"))
+	assert.Equal(t, 1, d.Get("job_settings.0.max_concurrent_runs"))
+}
+
+func TestJobDataSource_NameErrors(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Report":  "there is more than one job with name 'Report': 2 and 3",
+		"Unknown": "there is no job with name 'Unknown'",
+		"ingest":  "there is no job with name 'ingest'",
+	} {
+		qa.ResourceFixture{
+			Fixtures:    jobsListFixtures,
+			Resource:    DataSourceJob(),
+			Read:        true,
+			NonWritable: true,
+			ID:          "_",
+			HCL:         `job_name = "` + name + `"`,
+		}.ExpectError(t, expected)
+	}
+}
+
+func TestJobDataSource_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=5",
+				Status:   400,
+				Response: map[string]string{
+					"error_code": "INVALID_PARAMETER_VALUE",
+					"message":    "Job 5 does not exist.",
+				},
+			},
+		},
+		Resource:    DataSourceJob(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `job_id = "5"`,
+	}.ExpectError(t, "Job 5 does not exist.")
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceJobs returns IDs of all jobs by their names
+func DataSourceJobs() *schema.Resource {
+	type jobsData struct {
+		IDs map[string]string `json:"ids,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(jobsData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			list, err := NewJobsAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this := jobsData{
+				IDs: map[string]string{},
+			}
+			for _, job := range list.Jobs {
+				if job.Settings == nil {
+					continue
+				}
+				name := job.Settings.Name
+				if id, ok := this.IDs[name]; ok {
+					return diag.Errorf("there is more than one job with name '%s': %s and %d",
+						name, id, job.JobID)
+				}
+				this.IDs[name] = job.ID()
+			}
+			d.SetId("_")
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobsDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/list?limit=25",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID: 1,
+							Settings: &JobSettings{
+								Name: "Ingest",
+							},
+						},
+						{
+							JobID: 2,
+							Settings: &JobSettings{
+								Name: "Report",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceJobs(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"Ingest": "1",
+		"Report": "2",
+	}, d.Get("ids"))
+}
+
+func TestJobsDataSource_AmbiguousName(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/list?limit=25",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID: 1,
+							Settings: &JobSettings{
+								Name: "Report",
+							},
+						},
+					},
+					HasMore: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/list?limit=25&offset=1",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID: 2,
+							Settings: &JobSettings{
+								Name: "Report",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource:    DataSourceJobs(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "there is more than one job with name 'Report': 1 and 2")
+}
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+)
+
+// jobPermissions is the subset of Permissions API response, that is needed to find the job owner.
+// Permissions are fully managed by databricks_permissions resource in the access package.
+type jobPermissions struct {
+	AccessControlList []jobAccessControl `json:"access_control_list"`
+}
+
+type jobAccessControl struct {
+	UserName             string          `json:"user_name,omitempty"`
+	ServicePrincipalName string          `json:"service_principal_name,omitempty"`
+	AllPermissions       []jobPermission `json:"all_permissions,omitempty"`
+	PermissionLevel      string          `json:"permission_level,omitempty"`
+}
+
+type jobPermission struct {
+	PermissionLevel string `json:"permission_level"`
+	Inherited       bool   `json:"inherited,omitempty"`
+}
+
+type jobOwnerChange struct {
+	UserName             string `json:"user_name,omitempty"`
+	ServicePrincipalName string `json:"service_principal_name,omitempty"`
+	PermissionLevel      string `json:"permission_level"`
+}
+
+type jobOwnerChangeList struct {
+	AccessControlList []jobOwnerChange `json:"access_control_list"`
+}
+
+// service principals are referred by their application ID
+var applicationIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ownerChange returns the access control change, that makes the user or service principal an owner
+func ownerChange(owner string) jobOwnerChangeList {
+	change := jobOwnerChange{UserName: owner, PermissionLevel: "IS_OWNER"}
+	if applicationIDRegex.MatchString(owner) {
+		change = jobOwnerChange{ServicePrincipalName: owner, PermissionLevel: "IS_OWNER"}
+	}
+	return jobOwnerChangeList{
+		AccessControlList: []jobOwnerChange{change},
+	}
+}
+
+// owner returns user name or application ID of the principal with direct IS_OWNER permission
+func (p jobPermissions) owner() string {
+	for _, ac := range p.AccessControlList {
+		isOwner := ac.PermissionLevel == "IS_OWNER"
+		for _, permission := range ac.AllPermissions {
+			if !permission.Inherited && permission.PermissionLevel == "IS_OWNER" {
+				isOwner = true
+			}
+		}
+		if !isOwner {
+			continue
+		}
+		if ac.ServicePrincipalName != "" {
+			return ac.ServicePrincipalName
+		}
+		return ac.UserName
+	}
+	return ""
+}
+
+// permissionsContext selects Permissions API version, that is not the same as of Jobs API 2.1
+func (a JobsAPI) permissionsContext() context.Context {
+	return context.WithValue(a.context, common.Api, common.API_2_0)
+}
+
+// Owner returns user name or application ID of the job owner
+func (a JobsAPI) Owner(id string) (string, error) {
+	var permissions jobPermissions
+	err := a.client.Get(a.permissionsContext(), fmt.Sprintf("/permissions/jobs/%s", id), nil, &permissions)
+	if err != nil {
+		return "", wrapMissingJobError(err, id)
+	}
+	return permissions.owner(), nil
+}
+
+// TransferOwnership makes the user or service principal an owner of the job. Only the IS_OWNER entry
+// is updated, so that the rest of access control list, possibly managed by databricks_permissions,
+// stays intact.
+func (a JobsAPI) TransferOwnership(id, owner string) error {
+	return wrapMissingJobError(a.client.Patch(a.permissionsContext(),
+		fmt.Sprintf("/permissions/jobs/%s", id), ownerChange(owner)), id)
+}
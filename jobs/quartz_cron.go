@@ -0,0 +1,349 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	// timezones are validated the same way on machines without zoneinfo database
+	_ "time/tzdata"
+)
+
+// cronField describes allowed values of one of the fields of Quartz cron expression
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	cronSeconds     = cronField{name: "seconds", min: 0, max: 59}
+	cronMinutes     = cronField{name: "minutes", min: 0, max: 59}
+	cronHours       = cronField{name: "hours", min: 0, max: 23}
+	cronDaysOfMonth = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonths      = cronField{name: "month", min: 1, max: 12, names: []string{
+		"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	cronDaysOfWeek = cronField{name: "day-of-week", min: 1, max: 7, names: []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+	cronYears = cronField{name: "year", min: 1970, max: 2099}
+)
+
+// value parses number or a name, where names start from the minimal value
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid %s", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d is not between %d and %d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// parse returns allowed values of the field from lists, ranges and increments, like 1,5-10,20/5.
+// Ranges, like 22-2 or FRI-MON, wrap around the end of the field, except for years.
+func (f cronField) parse(s string) (map[int]bool, error) {
+	size := f.max - f.min + 1
+	values := map[int]bool{}
+	for _, item := range strings.Split(s, ",") {
+		step, hasStep := 1, false
+		if i := strings.Index(item, "/"); i >= 0 {
+			hasStep = true
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("increment %s of %s must be a positive number", item[i+1:], f.name)
+			}
+			item = item[:i]
+		}
+		start, end := f.min, f.max
+		switch {
+		case item == "*" || (item == "" && hasStep):
+		case strings.Contains(item, "-"):
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if start, err = f.value(bounds[0]); err != nil {
+				return nil, err
+			}
+			if end, err = f.value(bounds[1]); err != nil {
+				return nil, err
+			}
+			if start > end {
+				if f.name == cronYears.name {
+					return nil, fmt.Errorf("%s range %s has start after end", f.name, item)
+				}
+				end += size
+			}
+		default:
+			var err error
+			if start, err = f.value(item); err != nil {
+				return nil, err
+			}
+			if step == 1 {
+				end = start
+			}
+		}
+		for v := start; v <= end; v += step {
+			values[f.min+(v-f.min)%size] = true
+		}
+	}
+	return values, nil
+}
+
+// quartzCron is a parsed Quartz cron expression: seconds, minutes, hours, day-of-month,
+// month, day-of-week and optional year. See http://www.quartz-scheduler.org/documentation/quartz-2.3.0/tutorials/crontrigger.html
+type quartzCron struct {
+	seconds, minutes, hours, months, years map[int]bool
+
+	// exactly one of day-of-month and day-of-week is ?
+	daysOfMonth        map[int]bool
+	lastDayOfMonth     bool
+	lastDayOffset      int
+	lastWeekdayOfMonth bool
+	nearestWeekday     int
+
+	daysOfWeek    map[int]bool
+	lastDayOfWeek int
+	nthDayOfWeek  int
+	nth           int
+}
+
+var (
+	cronLastDayOffset = regexp.MustCompile(`^L-(\d+)$`)
+	cronWeekday       = regexp.MustCompile(`^(\d+)W$`)
+	cronLastDayOfWeek = regexp.MustCompile(`^(\w+)L$`)
+	cronNthDayOfWeek  = regexp.MustCompile(`^(\w+)#(\d)$`)
+)
+
+func (c *quartzCron) parseDayOfMonth(s string) (err error) {
+	switch {
+	case s == "L":
+		c.lastDayOfMonth = true
+	case s == "LW":
+		c.lastWeekdayOfMonth = true
+	case cronLastDayOffset.MatchString(s):
+		c.lastDayOfMonth = true
+		c.lastDayOffset, _ = strconv.Atoi(cronLastDayOffset.FindStringSubmatch(s)[1])
+		if c.lastDayOffset > 30 {
+			return fmt.Errorf("offset from the last day of month must be at most 30")
+		}
+	case cronWeekday.MatchString(s):
+		c.nearestWeekday, err = cronDaysOfMonth.value(cronWeekday.FindStringSubmatch(s)[1])
+	default:
+		c.daysOfMonth, err = cronDaysOfMonth.parse(s)
+	}
+	return
+}
+
+func (c *quartzCron) parseDayOfWeek(s string) (err error) {
+	switch {
+	case s == "L":
+		c.daysOfWeek = map[int]bool{7: true}
+	case cronLastDayOfWeek.MatchString(s):
+		c.lastDayOfWeek, err = cronDaysOfWeek.value(cronLastDayOfWeek.FindStringSubmatch(s)[1])
+	case cronNthDayOfWeek.MatchString(s):
+		m := cronNthDayOfWeek.FindStringSubmatch(s)
+		c.nth, _ = strconv.Atoi(m[2])
+		if c.nth < 1 || c.nth > 5 {
+			return fmt.Errorf("occurrence of day-of-week must be between 1 and 5, but got %d", c.nth)
+		}
+		c.nthDayOfWeek, err = cronDaysOfWeek.value(m[1])
+	default:
+		c.daysOfWeek, err = cronDaysOfWeek.parse(s)
+	}
+	return
+}
+
+// parseQuartzCron parses and validates Quartz cron expression, like `0 15 10 ? * MON-FRI`
+func parseQuartzCron(expr string) (*quartzCron, error) {
+	fields := strings.Fields(strings.ToUpper(expr))
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("expected 6 or 7 fields, but got %d", len(fields))
+	}
+	if len(fields) == 6 {
+		fields = append(fields, "*")
+	}
+	c := &quartzCron{}
+	var err error
+	for _, f := range []struct {
+		field  cronField
+		value  string
+		values *map[int]bool
+	}{
+		{cronSeconds, fields[0], &c.seconds},
+		{cronMinutes, fields[1], &c.minutes},
+		{cronHours, fields[2], &c.hours},
+		{cronMonths, fields[4], &c.months},
+		{cronYears, fields[6], &c.years},
+	} {
+		if *f.values, err = f.field.parse(f.value); err != nil {
+			return nil, err
+		}
+	}
+	dayOfMonth, dayOfWeek := fields[3], fields[5]
+	if (dayOfMonth == "?") == (dayOfWeek == "?") {
+		return nil, fmt.Errorf("exactly one of day-of-month and day-of-week must be ?")
+	}
+	if dayOfMonth != "?" {
+		err = c.parseDayOfMonth(dayOfMonth)
+	} else {
+		err = c.parseDayOfWeek(dayOfWeek)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekdayInMonth returns the weekday closest to the given day, that stays within the month
+func nearestWeekdayInMonth(year int, month time.Month, day int) int {
+	last := daysInMonth(year, month)
+	if day > last {
+		return 0
+	}
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func lastWeekdayInMonth(year int, month time.Month) int {
+	last := daysInMonth(year, month)
+	switch time.Date(year, month, last, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		return last - 1
+	case time.Sunday:
+		return last - 2
+	}
+	return last
+}
+
+// matchesDay tells if the schedule fires on the given calendar date
+func (c *quartzCron) matchesDay(day time.Time) bool {
+	year, month, dom := day.Date()
+	if !c.years[year] || !c.months[int(month)] {
+		return false
+	}
+	last := daysInMonth(year, month)
+	dow := int(day.Weekday()) + 1
+	switch {
+	case c.lastDayOfMonth:
+		return dom == last-c.lastDayOffset
+	case c.lastWeekdayOfMonth:
+		return dom == lastWeekdayInMonth(year, month)
+	case c.nearestWeekday > 0:
+		return dom == nearestWeekdayInMonth(year, month, c.nearestWeekday)
+	case c.daysOfMonth != nil:
+		return c.daysOfMonth[dom]
+	case c.lastDayOfWeek > 0:
+		return dow == c.lastDayOfWeek && dom+7 > last
+	case c.nthDayOfWeek > 0:
+		return dow == c.nthDayOfWeek && (dom-1)/7+1 == c.nth
+	}
+	return c.daysOfWeek[dow]
+}
+
+// firstTimeOfDay returns the first fire time of the day, that is not before the given time of day
+func (c *quartzCron) firstTimeOfDay(hour, min, sec int) (int, int, int, bool) {
+	for h := hour; h < 24; h++ {
+		if !c.hours[h] {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if !c.minutes[m] || (h == hour && m < min) {
+				continue
+			}
+			for s := 0; s < 60; s++ {
+				if !c.seconds[s] || (h == hour && m == min && s < sec) {
+					continue
+				}
+				return h, m, s, true
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// Next returns the first fire time strictly after the given time in the given timezone
+func (c *quartzCron) Next(after time.Time, loc *time.Location) (time.Time, bool) {
+	local := after.In(loc).Truncate(time.Second).Add(time.Second)
+	// calendar is walked in UTC, so that daylight saving time changes don't skip or repeat days
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	hour, min, sec := local.Hour(), local.Minute(), local.Second()
+	for day.Year() <= cronYears.max {
+		if c.matchesDay(day) {
+			if h, m, s, ok := c.firstTimeOfDay(hour, min, sec); ok {
+				next := time.Date(day.Year(), day.Month(), day.Day(), h, m, s, 0, loc)
+				if next.After(after) {
+					return next, true
+				}
+				// repeated wall clock time, when clocks go back
+				hour, min, sec = h, m, s+1
+				continue
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+		hour, min, sec = 0, 0, 0
+	}
+	return time.Time{}, false
+}
+
+// NextRuns returns up to count fire times after the given time
+func (c *quartzCron) NextRuns(after time.Time, loc *time.Location, count int) []time.Time {
+	runs := []time.Time{}
+	for len(runs) < count {
+		next, ok := c.Next(after, loc)
+		if !ok {
+			break
+		}
+		runs = append(runs, next)
+		after = next
+	}
+	return runs
+}
+
+// validateQuartzCron is a schema validation function for quartz_cron_expression
+func validateQuartzCron(i interface{}, k string) (_ []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := parseQuartzCron(v); err != nil {
+		errs = append(errs, fmt.Errorf("invalid value for %s (%s)", k, err))
+	}
+	return
+}
+
+// validateTimezoneID is a schema validation function for IANA timezone_id
+func validateTimezoneID(i interface{}, k string) (_ []string, errs []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := time.LoadLocation(v); err != nil || v == "" || v == "Local" {
+		errs = append(errs, fmt.Errorf("invalid value for %s (%s is not a known IANA timezone, "+
+			"like Europe/Amsterdam or UTC)", k, v))
+	}
+	return
+}
@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuartzCron_Errors(t *testing.T) {
+	for expr, expected := range map[string]string{
+		"0 0 * * *":             "expected 6 or 7 fields, but got 5",
+		"0 0 0 * * ? 2020 1":    "expected 6 or 7 fields, but got 8",
+		"60 0 0 * * ?":          "seconds 60 is not between 0 and 59",
+		"0 0 24 * * ?":          "hours 24 is not between 0 and 23",
+		"0 0 0 32 * ?":          "day-of-month 32 is not between 1 and 31",
+		"0 0 0 ? FOO *":         "\"FOO\" is not a valid month",
+		"0 0 0 ? * MON-FOO":     "\"FOO\" is not a valid day-of-week",
+		"0 0 0 ? * 8":           "day-of-week 8 is not between 1 and 7",
+		"0 0 0 1 * ? 2030-2025": "year range 2030-2025 has start after end",
+		"0 0/0 0 * * ?":         "increment 0 of minutes must be a positive number",
+		"0 0 0 * * *":           "exactly one of day-of-month and day-of-week must be ?",
+		"0 0 0 ? * ?":           "exactly one of day-of-month and day-of-week must be ?",
+		"0 0 0 ? * MON#6":       "occurrence of day-of-week must be between 1 and 5, but got 6",
+		"0 0 0 L-31 * ?":        "offset from the last day of month must be at most 30",
+		"0 0 0 1 * ? 1969":      "year 1969 is not between 1970 and 2099",
+		"0 0 0 1,,2 * ?":        "\"\" is not a valid day-of-month",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := parseQuartzCron(expr)
+			assert.EqualError(t, err, expected)
+		})
+	}
+}
+
+func nextRuns(t *testing.T, expr, tz, start string, count int) []string {
+	c, err := parseQuartzCron(expr)
+	require.NoError(t, err)
+	loc, err := time.LoadLocation(tz)
+	require.NoError(t, err)
+	after, err := time.Parse(time.RFC3339, start)
+	require.NoError(t, err)
+	runs := []string{}
+	for _, r := range c.NextRuns(after, loc, count) {
+		runs = append(runs, r.Format(time.RFC3339))
+	}
+	return runs
+}
+
+func TestQuartzCronNextRuns(t *testing.T) {
+	for name, tc := range map[string]struct {
+		expr     string
+		tz       string
+		start    string
+		expected []string
+	}{
+		"every 15 minutes": {
+			expr:  "0 0/15 * * * ?",
+			tz:    "UTC",
+			start: "2021-10-06T10:07:00Z",
+			expected: []string{
+				"2021-10-06T10:15:00Z",
+				"2021-10-06T10:30:00Z",
+				"2021-10-06T10:45:00Z",
+			},
+		},
+		"hours range over midnight": {
+			expr:  "0 0 22-2 * * ?",
+			tz:    "UTC",
+			start: "2021-10-06T21:30:00Z",
+			expected: []string{
+				"2021-10-06T22:00:00Z",
+				"2021-10-06T23:00:00Z",
+				"2021-10-07T00:00:00Z",
+				"2021-10-07T01:00:00Z",
+				"2021-10-07T02:00:00Z",
+				"2021-10-07T22:00:00Z",
+			},
+		},
+		"days of week range over weekend": {
+			expr:  "0 0 8 ? * FRI-MON",
+			tz:    "UTC",
+			start: "2021-10-06T00:00:00Z",
+			expected: []string{
+				"2021-10-08T08:00:00Z",
+				"2021-10-09T08:00:00Z",
+				"2021-10-10T08:00:00Z",
+				"2021-10-11T08:00:00Z",
+				"2021-10-15T08:00:00Z",
+			},
+		},
+		"increment without start": {
+			expr:  "0 /20 * * * ?",
+			tz:    "UTC",
+			start: "2021-10-06T10:07:00Z",
+			expected: []string{
+				"2021-10-06T10:20:00Z",
+				"2021-10-06T10:40:00Z",
+				"2021-10-06T11:00:00Z",
+			},
+		},
+		"weekdays in timezone": {
+			expr:  "0 15 10 ? * MON-FRI",
+			tz:    "Europe/Amsterdam",
+			start: "2021-10-08T08:00:00Z",
+			expected: []string{
+				"2021-10-08T10:15:00+02:00",
+				"2021-10-11T10:15:00+02:00",
+				"2021-10-12T10:15:00+02:00",
+			},
+		},
+		"exactly at fire time": {
+			expr:  "0 0 12 * * ?",
+			tz:    "UTC",
+			start: "2021-10-06T12:00:00Z",
+			expected: []string{
+				"2021-10-07T12:00:00Z",
+			},
+		},
+		"last day of month": {
+			expr:  "0 0 0 L * ?",
+			tz:    "UTC",
+			start: "2024-01-15T00:00:00Z",
+			expected: []string{
+				"2024-01-31T00:00:00Z",
+				"2024-02-29T00:00:00Z",
+				"2024-03-31T00:00:00Z",
+			},
+		},
+		"two days before the end of month": {
+			expr:  "0 0 0 L-2 * ?",
+			tz:    "UTC",
+			start: "2021-02-01T00:00:00Z",
+			expected: []string{
+				"2021-02-26T00:00:00Z",
+			},
+		},
+		"last weekday of month": {
+			expr:  "0 0 0 LW * ?",
+			tz:    "UTC",
+			start: "2021-07-01T00:00:00Z",
+			expected: []string{
+				"2021-07-30T00:00:00Z",
+			},
+		},
+		"nearest weekday": {
+			expr:  "0 0 0 1W * ?",
+			tz:    "UTC",
+			start: "2022-01-01T00:00:00Z",
+			expected: []string{
+				"2022-01-03T00:00:00Z",
+				"2022-02-01T00:00:00Z",
+			},
+		},
+		"last friday": {
+			expr:  "0 0 18 ? * 6L",
+			tz:    "UTC",
+			start: "2021-10-01T00:00:00Z",
+			expected: []string{
+				"2021-10-29T18:00:00Z",
+				"2021-11-26T18:00:00Z",
+			},
+		},
+		"second monday": {
+			expr:  "0 0 9 ? * MON#2",
+			tz:    "UTC",
+			start: "2021-10-01T00:00:00Z",
+			expected: []string{
+				"2021-10-11T09:00:00Z",
+				"2021-11-08T09:00:00Z",
+			},
+		},
+		"leap day": {
+			expr:  "0 0 0 29 FEB ?",
+			tz:    "UTC",
+			start: "2021-03-01T00:00:00Z",
+			expected: []string{
+				"2024-02-29T00:00:00Z",
+				"2028-02-29T00:00:00Z",
+			},
+		},
+		"year in the past": {
+			expr:     "0 0 0 1 1 ? 2020",
+			tz:       "UTC",
+			start:    "2021-01-01T00:00:00Z",
+			expected: []string{},
+		},
+		"skipped by daylight saving time": {
+			expr:  "0 30 2 * * ?",
+			tz:    "Europe/Amsterdam",
+			start: "2021-03-27T12:00:00Z",
+			expected: []string{
+				"2021-03-28T03:30:00+02:00",
+				"2021-03-29T02:30:00+02:00",
+			},
+		},
+		"repeated by daylight saving time": {
+			expr:  "0 30 2 * * ?",
+			tz:    "Europe/Amsterdam",
+			start: "2021-10-31T00:00:00Z",
+			expected: []string{
+				"2021-10-31T02:30:00+01:00",
+				"2021-11-01T02:30:00+01:00",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			count := len(tc.expected)
+			if count == 0 {
+				count = 1
+			}
+			assert.Equal(t, tc.expected, nextRuns(t, tc.expr, tc.tz, tc.start, count))
+		})
+	}
+}
+
+func TestValidateTimezoneID(t *testing.T) {
+	for tz, valid := range map[string]bool{
+		"UTC":              true,
+		"Europe/Amsterdam": true,
+		"America/New_York": true,
+		"Local":            false,
+		"":                 false,
+		"Mars/Olympus":     false,
+	} {
+		_, errs := validateTimezoneID(tz, "timezone_id")
+		assert.Equal(t, valid, len(errs) == 0, tz)
+	}
+}
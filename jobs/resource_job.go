@@ -16,6 +16,7 @@ import (
 	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+	"github.com/databrickslabs/terraform-provider-databricks/workspace"
 )
 
 // NotebookTask contains the information for notebook jobs
@@ -55,6 +56,39 @@ type PipelineTask struct {
 	PipelineID string `json:"pipeline_id"`
 }
 
+// SqlQueryTask refers to the databricks_sql_query, that is executed by the task
+type SqlQueryTask struct {
+	QueryID string `json:"query_id"`
+}
+
+// SqlDashboardTask refers to the databricks_sql_dashboard, that is refreshed by the task
+type SqlDashboardTask struct {
+	DashboardID string `json:"dashboard_id"`
+}
+
+// SqlAlertTask refers to the SQL alert, that is evaluated by the task
+type SqlAlertTask struct {
+	AlertID string `json:"alert_id"`
+}
+
+// SqlTask contains the information for SQL jobs, that run on databricks_sql_endpoint
+type SqlTask struct {
+	Query       *SqlQueryTask     `json:"query,omitempty"`
+	Dashboard   *SqlDashboardTask `json:"dashboard,omitempty"`
+	Alert       *SqlAlertTask     `json:"alert,omitempty"`
+	WarehouseID string            `json:"warehouse_id"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+}
+
+// DbtTask contains the information for dbt jobs, that take the project from git_source
+type DbtTask struct {
+	Commands          []string `json:"commands"`
+	ProjectDirectory  string   `json:"project_directory,omitempty"`
+	ProfilesDirectory string   `json:"profiles_directory,omitempty"`
+	Schema            string   `json:"schema,omitempty"`
+	WarehouseID       string   `json:"warehouse_id,omitempty"`
+}
+
 // EmailNotifications contains the information for email notifications after job completion
 type EmailNotifications struct {
 	OnStart               []string `json:"on_start,omitempty"`
@@ -63,6 +97,18 @@ type EmailNotifications struct {
 	NoAlertForSkippedRuns bool     `json:"no_alert_for_skipped_runs,omitempty"`
 }
 
+// Webhook refers to the notification destination by its ID
+type Webhook struct {
+	ID string `json:"id"`
+}
+
+// WebhookNotifications contains the notification destinations, that are called when runs of the job start and complete
+type WebhookNotifications struct {
+	OnStart   []Webhook `json:"on_start,omitempty" tf:"max_items:3"`
+	OnSuccess []Webhook `json:"on_success,omitempty" tf:"max_items:3"`
+	OnFailure []Webhook `json:"on_failure,omitempty" tf:"max_items:3"`
+}
+
 // CronSchedule contains the information for the quartz cron expression
 type CronSchedule struct {
 	QuartzCronExpression string `json:"quartz_cron_expression"`
@@ -79,20 +125,58 @@ type JobTaskSettings struct {
 	Description string           `json:"description,omitempty"`
 	DependsOn   []TaskDependency `json:"depends_on,omitempty"`
 
-	ExistingClusterID      string              `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
-	NewCluster             *clusters.Cluster   `json:"new_cluster,omitempty" tf:"group:cluster_type"`
-	Libraries              []libraries.Library `json:"libraries,omitempty" tf:"slice_set,alias:library"`
-	NotebookTask           *NotebookTask       `json:"notebook_task,omitempty" tf:"group:task_type"`
-	SparkJarTask           *SparkJarTask       `json:"spark_jar_task,omitempty" tf:"group:task_type"`
-	SparkPythonTask        *SparkPythonTask    `json:"spark_python_task,omitempty" tf:"group:task_type"`
-	SparkSubmitTask        *SparkSubmitTask    `json:"spark_submit_task,omitempty" tf:"group:task_type"`
-	PipelineTask           *PipelineTask       `json:"pipeline_task,omitempty" tf:"group:task_type"`
-	PythonWheelTask        *PythonWheelTask    `json:"python_wheel_task,omitempty" tf:"group:task_type"`
-	EmailNotifications     *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
-	TimeoutSeconds         int32               `json:"timeout_seconds,omitempty"`
-	MaxRetries             int32               `json:"max_retries,omitempty"`
-	MinRetryIntervalMillis int32               `json:"min_retry_interval_millis,omitempty"`
-	RetryOnTimeout         bool                `json:"retry_on_timeout,omitempty" tf:"computed"`
+	ExistingClusterID      string                `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
+	NewCluster             *clusters.Cluster     `json:"new_cluster,omitempty" tf:"group:cluster_type"`
+	JobClusterKey          string                `json:"job_cluster_key,omitempty" tf:"group:cluster_type"`
+	Libraries              []libraries.Library   `json:"libraries,omitempty" tf:"slice_set,alias:library"`
+	NotebookTask           *NotebookTask         `json:"notebook_task,omitempty" tf:"group:task_type"`
+	SparkJarTask           *SparkJarTask         `json:"spark_jar_task,omitempty" tf:"group:task_type"`
+	SparkPythonTask        *SparkPythonTask      `json:"spark_python_task,omitempty" tf:"group:task_type"`
+	SparkSubmitTask        *SparkSubmitTask      `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	PipelineTask           *PipelineTask         `json:"pipeline_task,omitempty" tf:"group:task_type"`
+	PythonWheelTask        *PythonWheelTask      `json:"python_wheel_task,omitempty" tf:"group:task_type"`
+	SqlTask                *SqlTask              `json:"sql_task,omitempty" tf:"group:task_type"`
+	DbtTask                *DbtTask              `json:"dbt_task,omitempty" tf:"group:task_type"`
+	EmailNotifications     *EmailNotifications   `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	WebhookNotifications   *WebhookNotifications `json:"webhook_notifications,omitempty"`
+	TimeoutSeconds         int32                 `json:"timeout_seconds,omitempty"`
+	MaxRetries             int32                 `json:"max_retries,omitempty"`
+	MinRetryIntervalMillis int32                 `json:"min_retry_interval_millis,omitempty"`
+	RetryOnTimeout         bool                  `json:"retry_on_timeout,omitempty" tf:"computed"`
+}
+
+// GitSource contains the Git repository, from which notebooks and Python files of the job are taken
+type GitSource struct {
+	Url      string `json:"git_url" tf:"alias:url"`
+	Provider string `json:"git_provider,omitempty" tf:"computed,alias:provider"`
+	Branch   string `json:"git_branch,omitempty" tf:"alias:branch"`
+	Tag      string `json:"git_tag,omitempty" tf:"alias:tag"`
+	Commit   string `json:"git_commit,omitempty" tf:"alias:commit"`
+}
+
+// resolveProvider detects Git provider from the URL the same way as databricks_repo does
+func (gs *GitSource) resolveProvider() error {
+	if gs == nil || gs.Provider != "" {
+		return nil
+	}
+	gs.Provider = workspace.GetGitProviderFromUrl(gs.Url)
+	if gs.Provider == "" {
+		return fmt.Errorf("git_source.0.provider isn't specified and we can't "+
+			"detect provider from URL %s", gs.Url)
+	}
+	return nil
+}
+
+// RunAs is the identity, which the job runs as. Only one of user or service principal can be set.
+type RunAs struct {
+	UserName             string `json:"user_name,omitempty"`
+	ServicePrincipalName string `json:"service_principal_name,omitempty"`
+}
+
+// JobCluster is a cluster specification, that is shared by the tasks of the job
+type JobCluster struct {
+	JobClusterKey string            `json:"job_cluster_key"`
+	NewCluster    *clusters.Cluster `json:"new_cluster"`
 }
 
 // JobSettings contains the information for configuring a job on databricks
@@ -116,28 +200,44 @@ type JobSettings struct {
 	// END Jobs API 2.0
 
 	// BEGIN Jobs API 2.1
-	Tasks  []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
-	Format string            `json:"format,omitempty" tf:"computed"`
+	Tasks       []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
+	JobClusters []JobCluster      `json:"job_clusters,omitempty" tf:"alias:job_cluster"`
+	GitSource   *GitSource        `json:"git_source,omitempty"`
+	RunAs       *RunAs            `json:"run_as,omitempty" tf:"suppress_diff"`
+	Format      string            `json:"format,omitempty" tf:"computed"`
 	// END Jobs API 2.1
 
-	Schedule           *CronSchedule       `json:"schedule,omitempty"`
-	MaxConcurrentRuns  int32               `json:"max_concurrent_runs,omitempty"`
-	EmailNotifications *EmailNotifications `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	Schedule             *CronSchedule         `json:"schedule,omitempty"`
+	MaxConcurrentRuns    int32                 `json:"max_concurrent_runs,omitempty"`
+	EmailNotifications   *EmailNotifications   `json:"email_notifications,omitempty" tf:"suppress_diff"`
+	WebhookNotifications *WebhookNotifications `json:"webhook_notifications,omitempty"`
 }
 
 func (js *JobSettings) isMultiTask() bool {
-	return js.Format == "MULTI_TASK" || len(js.Tasks) > 0
+	return js.Format == "MULTI_TASK" || len(js.Tasks) > 0 ||
+		len(js.JobClusters) > 0 || js.GitSource != nil || js.RunAs != nil ||
+		js.WebhookNotifications != nil
 }
 
 func (js *JobSettings) sortTasksByKey() {
 	sort.Slice(js.Tasks, func(i, j int) bool {
 		return js.Tasks[i].TaskKey < js.Tasks[j].TaskKey
 	})
+	sort.Slice(js.JobClusters, func(i, j int) bool {
+		return js.JobClusters[i].JobClusterKey < js.JobClusters[j].JobClusterKey
+	})
 }
 
 // JobList returns a list of all jobs
 type JobList struct {
-	Jobs []Job `json:"jobs"`
+	Jobs    []Job `json:"jobs"`
+	HasMore bool  `json:"has_more,omitempty"`
+}
+
+// JobListRequest used to get a page of jobs
+type JobListRequest struct {
+	Offset int32 `url:"offset,omitempty"`
+	Limit  int32 `url:"limit,omitempty"`
 }
 
 // Job contains the information when using a GET request from the Databricks Jobs api
@@ -171,19 +271,51 @@ type RunState struct {
 	StateMessage   string `json:"state_message,omitempty"`
 }
 
+// IsTerminal tells if run has reached one of the final life cycle states
+func (rs RunState) IsTerminal() bool {
+	switch rs.LifeCycleState {
+	case "TERMINATED", "SKIPPED", "INTERNAL_ERROR":
+		return true
+	}
+	return false
+}
+
+// RunTask is a run of one of the tasks within multi-task job run
+type RunTask struct {
+	RunID   int64    `json:"run_id"`
+	TaskKey string   `json:"task_key,omitempty"`
+	State   RunState `json:"state"`
+}
+
 // JobRun is a simplified representation of corresponding entity
 type JobRun struct {
-	JobID       int64    `json:"job_id"`
-	RunID       int64    `json:"run_id"`
-	NumberInJob int64    `json:"number_in_job"`
-	StartTime   int64    `json:"start_time,omitempty"`
-	State       RunState `json:"state"`
-	Trigger     string   `json:"trigger,omitempty"`
-	RuntType    string   `json:"run_type,omitempty"`
+	JobID       int64     `json:"job_id"`
+	RunID       int64     `json:"run_id"`
+	NumberInJob int64     `json:"number_in_job"`
+	StartTime   int64     `json:"start_time,omitempty"`
+	State       RunState  `json:"state"`
+	Trigger     string    `json:"trigger,omitempty"`
+	RuntType    string    `json:"run_type,omitempty"`
+	RunPageURL  string    `json:"run_page_url,omitempty"`
+	Tasks       []RunTask `json:"tasks,omitempty"`
 
 	OverridingParameters RunParameters `json:"overriding_parameters,omitempty"`
 }
 
+// NotebookOutput contains the value passed to dbutils.notebook.exit()
+type NotebookOutput struct {
+	Result    string `json:"result,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// RunOutput is the output of a single-task run
+type RunOutput struct {
+	NotebookOutput *NotebookOutput `json:"notebook_output,omitempty"`
+	Logs           string          `json:"logs,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	ErrorTrace     string          `json:"error_trace,omitempty"`
+}
+
 // JobRunsListRequest used to do what it sounds like
 type JobRunsListRequest struct {
 	JobID         int64 `url:"job_id,omitempty"`
@@ -217,10 +349,21 @@ type JobsAPI struct {
 	context context.Context
 }
 
-// List all jobs
+// List all jobs, fetching them page by page
 func (a JobsAPI) List() (l JobList, err error) {
-	err = a.client.Get(a.context, "/jobs/list", nil, &l)
-	return
+	req := JobListRequest{Limit: 25}
+	for {
+		var page JobList
+		err = a.client.Get(a.context, "/jobs/list", req, &page)
+		if err != nil {
+			return
+		}
+		l.Jobs = append(l.Jobs, page.Jobs...)
+		if !page.HasMore || len(page.Jobs) == 0 {
+			return
+		}
+		req.Offset += int32(len(page.Jobs))
+	}
 }
 
 // RunsList returns a job runs list
@@ -264,15 +407,56 @@ func (a JobsAPI) waitForRunState(runID int64, desiredState string, timeout time.
 	})
 }
 
+// waitForRunCompletion waits until the run reaches one of the terminal states and returns it
+func (a JobsAPI) waitForRunCompletion(runID int64, timeout time.Duration) (jobRun JobRun, err error) {
+	err = resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		jobRun, err = a.RunsGet(runID)
+		if err != nil {
+			return resource.NonRetryableError(
+				fmt.Errorf("cannot get run %d: %w", runID, err))
+		}
+		if jobRun.State.IsTerminal() {
+			return nil
+		}
+		return resource.RetryableError(
+			fmt.Errorf("run %d is %s: %s", runID,
+				jobRun.State.LifeCycleState,
+				jobRun.State.StateMessage))
+	})
+	return
+}
+
 // RunNow triggers the job and returns a run ID
 func (a JobsAPI) RunNow(jobID int64) (int64, error) {
-	var jr JobRun
-	err := a.client.Post(a.context, "/jobs/run-now", RunParameters{
+	return a.RunNowWithParameters(RunParameters{
 		JobID: jobID,
-	}, &jr)
+	})
+}
+
+// RunNowWithParameters triggers the job with overriding parameters and returns a run ID
+func (a JobsAPI) RunNowWithParameters(params RunParameters) (int64, error) {
+	var jr JobRun
+	err := a.client.Post(a.context, "/jobs/run-now", params, &jr)
+	return jr.RunID, err
+}
+
+// RunsSubmit triggers one-time run, that doesn't create a job, and returns a run ID
+func (a JobsAPI) RunsSubmit(run SubmitRun) (int64, error) {
+	var jr JobRun
+	run.sortTasksByKey()
+	err := a.client.Post(a.context, "/jobs/runs/submit", run, &jr)
 	return jr.RunID, err
 }
 
+// RunsGetOutput retrieves the output of a single-task run
+func (a JobsAPI) RunsGetOutput(runID int64) (RunOutput, error) {
+	var ro RunOutput
+	err := a.client.Get(a.context, "/jobs/runs/get-output", map[string]interface{}{
+		"run_id": runID,
+	}, &ro)
+	return ro, err
+}
+
 // RunsGet to retrieve information about the run
 func (a JobsAPI) RunsGet(runID int64) (JobRun, error) {
 	var jr JobRun
@@ -410,9 +594,37 @@ var jobSchema = common.StructToSchema(JobSettings{},
 	func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		jobSettingsSchema(&s, "")
 		jobSettingsSchema(&s["task"].Elem.(*schema.Resource).Schema, "task.0.")
+		jobSettingsSchema(&s["job_cluster"].Elem.(*schema.Resource).Schema, "job_cluster.0.")
 		if p, err := common.SchemaPath(s, "schedule", "pause_status"); err == nil {
 			p.ValidateFunc = validation.StringInSlice([]string{"PAUSED", "UNPAUSED"}, false)
 		}
+		if p, err := common.SchemaPath(s, "schedule", "quartz_cron_expression"); err == nil {
+			p.ValidateFunc = validateQuartzCron
+		}
+		if p, err := common.SchemaPath(s, "schedule", "timezone_id"); err == nil {
+			p.ValidateFunc = validateTimezoneID
+		}
+		if p, err := common.SchemaPath(s, "git_source", "url"); err == nil {
+			p.ValidateFunc = validation.IsURLWithScheme([]string{"https", "http"})
+		}
+		if p, err := common.SchemaPath(s, "git_source", "provider"); err == nil {
+			p.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			}
+		}
+		gitRefs := []string{"git_source.0.branch", "git_source.0.tag", "git_source.0.commit"}
+		for _, ref := range []string{"branch", "tag", "commit"} {
+			if p, err := common.SchemaPath(s, "git_source", ref); err == nil {
+				p.ExactlyOneOf = gitRefs
+				p.ValidateFunc = validation.StringIsNotWhiteSpace
+			}
+		}
+		runAs := []string{"run_as.0.user_name", "run_as.0.service_principal_name"}
+		for _, principal := range []string{"user_name", "service_principal_name"} {
+			if p, err := common.SchemaPath(s, "run_as", principal); err == nil {
+				p.ExactlyOneOf = runAs
+			}
+		}
 		s["max_concurrent_runs"].ValidateDiagFunc = validation.ToDiagFunc(validation.IntAtLeast(1))
 		s["max_concurrent_runs"].Default = 1
 		s["url"] = &schema.Schema{
@@ -424,6 +636,14 @@ var jobSchema = common.StructToSchema(JobSettings{},
 			Default:  false,
 			Type:     schema.TypeBool,
 		}
+		s["owner"] = &schema.Schema{
+			Optional:     true,
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			},
+		}
 		return s
 	})
 
@@ -457,18 +677,48 @@ func ResourceJob() *schema.Resource {
 			if alwaysRunning && js.MaxConcurrentRuns > 1 {
 				return fmt.Errorf("`always_running` must be specified only with `max_concurrent_runs = 1`")
 			}
-			for _, task := range js.Tasks {
+			if err = js.validateTasks(d.NewValueKnown); err != nil {
+				return err
+			}
+			if d.NewValueKnown("git_source.0.url") {
+				if err = js.GitSource.resolveProvider(); err != nil {
+					return err
+				}
+			}
+			for i, task := range js.Tasks {
 				if task.NewCluster == nil {
 					continue
 				}
 				if err = task.NewCluster.Validate(); err != nil {
 					return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
 				}
+				err = clusters.ValidateClusterPolicy(ctx, d, m, *task.NewCluster,
+					fmt.Sprintf("task.%d.new_cluster.0.", i), "job")
+				if err != nil {
+					return fmt.Errorf("task %s invalid: %w", task.TaskKey, err)
+				}
+			}
+			for i, jc := range js.JobClusters {
+				if jc.NewCluster == nil {
+					continue
+				}
+				if err = jc.NewCluster.Validate(); err != nil {
+					return fmt.Errorf("job cluster %s invalid: %w", jc.JobClusterKey, err)
+				}
+				err = clusters.ValidateClusterPolicy(ctx, d, m, *jc.NewCluster,
+					fmt.Sprintf("job_cluster.%d.new_cluster.0.", i), "job")
+				if err != nil {
+					return fmt.Errorf("job cluster %s invalid: %w", jc.JobClusterKey, err)
+				}
 			}
 			if js.NewCluster != nil {
 				if err = js.NewCluster.Validate(); err != nil {
 					return fmt.Errorf("invalid job cluster: %w", err)
 				}
+				err = clusters.ValidateClusterPolicy(ctx, d, m, *js.NewCluster, "new_cluster.0.", "job")
+				if err != nil {
+					return fmt.Errorf("invalid job cluster: %w", err)
+				}
 			}
 			return nil
 		},
@@ -478,6 +728,9 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if err = js.GitSource.resolveProvider(); err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -487,18 +740,38 @@ func ResourceJob() *schema.Resource {
 				return err
 			}
 			d.SetId(job.ID())
+			if owner := d.Get("owner").(string); owner != "" {
+				if err = jobsAPI.TransferOwnership(job.ID(), owner); err != nil {
+					return err
+				}
+			}
 			if d.Get("always_running").(bool) {
-				return jobsAPI.Start(job.JobID, d.Timeout(schema.TimeoutCreate))
+				err = jobsAPI.Start(job.JobID, d.Timeout(schema.TimeoutCreate))
+				if err != nil {
+					return err
+				}
+			}
+			if warnings := js.taskWarnings(); len(warnings) > 0 {
+				return common.Warningf("%s", strings.Join(warnings, "; "))
 			}
 			return nil
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			ctx = getReadCtx(ctx, d)
-			job, err := NewJobsAPI(ctx, c).Read(d.Id())
+			jobsAPI := NewJobsAPI(ctx, c)
+			job, err := jobsAPI.Read(d.Id())
 			if err != nil {
 				return err
 			}
 			d.Set("url", c.FormatURL("#job/", d.Id()))
+			if d.Get("owner").(string) != "" {
+				// ownership is checked only when it's managed by this resource
+				owner, err := jobsAPI.Owner(d.Id())
+				if err != nil {
+					return err
+				}
+				d.Set("owner", owner)
+			}
 			return common.StructToData(*job.Settings, jobSchema, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -507,6 +780,9 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if err = js.GitSource.resolveProvider(); err != nil {
+				return err
+			}
 			if js.isMultiTask() {
 				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
 			}
@@ -515,8 +791,19 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if owner := d.Get("owner").(string); owner != "" && d.HasChange("owner") {
+				if err = jobsAPI.TransferOwnership(d.Id(), owner); err != nil {
+					return err
+				}
+			}
 			if d.Get("always_running").(bool) {
-				return jobsAPI.Restart(d.Id(), d.Timeout(schema.TimeoutUpdate))
+				err = jobsAPI.Restart(d.Id(), d.Timeout(schema.TimeoutUpdate))
+				if err != nil {
+					return err
+				}
+			}
+			if warnings := js.taskWarnings(); len(warnings) > 0 {
+				return common.Warningf("%s", strings.Join(warnings, "; "))
 			}
 			return nil
 		},
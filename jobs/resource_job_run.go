@@ -0,0 +1,245 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/libraries"
+)
+
+// SubmitRun contains the settings of one-time run, that doesn't create a job
+type SubmitRun struct {
+	RunName string `json:"run_name,omitempty"`
+
+	// BEGIN Jobs API 2.0
+	ExistingClusterID string              `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
+	NewCluster        *clusters.Cluster   `json:"new_cluster,omitempty" tf:"group:cluster_type"`
+	NotebookTask      *NotebookTask       `json:"notebook_task,omitempty" tf:"group:task_type"`
+	SparkJarTask      *SparkJarTask       `json:"spark_jar_task,omitempty" tf:"group:task_type"`
+	SparkPythonTask   *SparkPythonTask    `json:"spark_python_task,omitempty" tf:"group:task_type"`
+	SparkSubmitTask   *SparkSubmitTask    `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	PipelineTask      *PipelineTask       `json:"pipeline_task,omitempty" tf:"group:task_type"`
+	PythonWheelTask   *PythonWheelTask    `json:"python_wheel_task,omitempty" tf:"group:task_type"`
+	Libraries         []libraries.Library `json:"libraries,omitempty" tf:"slice_set,alias:library"`
+	// END Jobs API 2.0
+
+	// BEGIN Jobs API 2.1
+	Tasks []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
+	// END Jobs API 2.1
+
+	TimeoutSeconds   int32  `json:"timeout_seconds,omitempty"`
+	IdempotencyToken string `json:"idempotency_token,omitempty"`
+}
+
+func (sr *SubmitRun) sortTasksByKey() {
+	sort.Slice(sr.Tasks, func(i, j int) bool {
+		return sr.Tasks[i].TaskKey < sr.Tasks[j].TaskKey
+	})
+}
+
+// JobRunSettings triggers either a run of existing job or one-time run
+type JobRunSettings struct {
+	JobID             int64             `json:"job_id,omitempty"`
+	NotebookParams    map[string]string `json:"notebook_params,omitempty"`
+	JarParams         []string          `json:"jar_params,omitempty"`
+	PythonParams      []string          `json:"python_params,omitempty"`
+	SparkSubmitParams []string          `json:"spark_submit_params,omitempty"`
+	Submit            *SubmitRun        `json:"submit,omitempty"`
+	Keepers           map[string]string `json:"keepers,omitempty"`
+	WaitForCompletion bool              `json:"wait_for_completion,omitempty" tf:"default:true"`
+
+	RunID          int64  `json:"run_id,omitempty" tf:"computed"`
+	RunPageURL     string `json:"run_page_url,omitempty" tf:"computed"`
+	LifeCycleState string `json:"life_cycle_state,omitempty" tf:"computed"`
+	ResultState    string `json:"result_state,omitempty" tf:"computed"`
+	StateMessage   string `json:"state_message,omitempty" tf:"computed"`
+	Output         string `json:"output,omitempty" tf:"computed"`
+	Error          string `json:"error,omitempty" tf:"computed"`
+}
+
+func (rs *JobRunSettings) isMultiTask() bool {
+	return rs.Submit != nil && len(rs.Submit.Tasks) > 0
+}
+
+func (rs *JobRunSettings) runParameters() RunParameters {
+	return RunParameters{
+		JobID:             rs.JobID,
+		NotebookParams:    rs.NotebookParams,
+		JarParams:         rs.JarParams,
+		PythonParams:      rs.PythonParams,
+		SparkSubmitParams: rs.SparkSubmitParams,
+	}
+}
+
+// isMissingRun tells if the run was removed, which happens 60 days after its completion
+func isMissingRun(err error, runID int64) bool {
+	if err == nil {
+		return false
+	}
+	return common.IsMissing(err) ||
+		strings.Contains(err.Error(), fmt.Sprintf("Run %d does not exist", runID))
+}
+
+// outputRunID returns ID of the run, that has the output. Multi-task runs have
+// outputs only for the runs of their tasks.
+func (jr JobRun) outputRunID() (int64, bool) {
+	switch len(jr.Tasks) {
+	case 0:
+		return jr.RunID, true
+	case 1:
+		return jr.Tasks[0].RunID, true
+	}
+	return 0, false
+}
+
+func setJobRunState(jobsAPI JobsAPI, jobRun JobRun, d *schema.ResourceData) error {
+	var output RunOutput
+	if outputRunID, ok := jobRun.outputRunID(); ok && jobRun.State.IsTerminal() {
+		var err error
+		output, err = jobsAPI.RunsGetOutput(outputRunID)
+		if err != nil {
+			// output is not available for some of the task types
+			log.Printf("[WARN] Cannot get output of run %d: %v", outputRunID, err)
+		}
+	}
+	result := output.Logs
+	if output.NotebookOutput != nil {
+		result = output.NotebookOutput.Result
+	}
+	for k, v := range map[string]interface{}{
+		"run_id":           jobRun.RunID,
+		"run_page_url":     jobRun.RunPageURL,
+		"life_cycle_state": jobRun.State.LifeCycleState,
+		"result_state":     jobRun.State.ResultState,
+		"state_message":    jobRun.State.StateMessage,
+		"output":           result,
+		"error":            output.Error,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var jobRunSchema = common.StructToSchema(JobRunSettings{},
+	func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		submit := s["submit"].Elem.(*schema.Resource)
+		jobSettingsSchema(&submit.Schema, "submit.0.")
+		jobSettingsSchema(&submit.Schema["task"].Elem.(*schema.Resource).Schema, "submit.0.task.0.")
+		s["job_id"].ExactlyOneOf = []string{"job_id", "submit"}
+		s["submit"].ExactlyOneOf = []string{"job_id", "submit"}
+		for _, k := range []string{"notebook_params", "jar_params",
+			"python_params", "spark_submit_params"} {
+			s[k].ConflictsWith = []string{"submit"}
+		}
+		return s
+	})
+
+// ResourceJobRun triggers a run of a job or one-time run on every change of arguments
+func ResourceJobRun() *schema.Resource {
+	getRunCtx := func(ctx context.Context, d *schema.ResourceData) context.Context {
+		var rs JobRunSettings
+		err := common.DataToStructPointer(d, jobRunSchema, &rs)
+		if err != nil {
+			log.Printf("[INFO] no job run resource data available. Returning default context")
+			return ctx
+		}
+		if rs.isMultiTask() {
+			return context.WithValue(ctx, common.Api, common.API_2_1)
+		}
+		return ctx
+	}
+	return common.Resource{
+		Schema: jobRunSchema,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
+			Delete: schema.DefaultTimeout(clusters.DefaultProvisionTimeout),
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var rs JobRunSettings
+			err := common.DataToStructPointer(d, jobRunSchema, &rs)
+			if err != nil {
+				return err
+			}
+			if rs.isMultiTask() {
+				ctx = context.WithValue(ctx, common.Api, common.API_2_1)
+			}
+			jobsAPI := NewJobsAPI(ctx, c)
+			var runID int64
+			if rs.Submit != nil {
+				runID, err = jobsAPI.RunsSubmit(*rs.Submit)
+			} else {
+				runID, err = jobsAPI.RunNowWithParameters(rs.runParameters())
+			}
+			if err != nil {
+				return err
+			}
+			d.SetId(fmt.Sprintf("%d", runID))
+			if !rs.WaitForCompletion {
+				return nil
+			}
+			jobRun, err := jobsAPI.waitForRunCompletion(runID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				return err
+			}
+			if jobRun.State.ResultState == "SUCCESS" {
+				return nil
+			}
+			// failed run is saved to the state, so that it's tainted and triggered on the next apply
+			if err = setJobRunState(jobsAPI, jobRun, d); err != nil {
+				return err
+			}
+			status := jobRun.State.ResultState
+			if status == "" {
+				status = jobRun.State.LifeCycleState
+			}
+			return fmt.Errorf("run %d is %s: %s. See %s", runID, status,
+				jobRun.State.StateMessage, jobRun.RunPageURL)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			runID, err := strconv.ParseInt(d.Id(), 10, 64)
+			if err != nil {
+				return err
+			}
+			jobsAPI := NewJobsAPI(getRunCtx(ctx, d), c)
+			jobRun, err := jobsAPI.RunsGet(runID)
+			if isMissingRun(err, runID) {
+				// removal of old run must not trigger it once again
+				log.Printf("[WARN] Run %d is no longer available, keeping the last known state", runID)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return setJobRunState(jobsAPI, jobRun, d)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			runID, err := strconv.ParseInt(d.Id(), 10, 64)
+			if err != nil {
+				return err
+			}
+			jobsAPI := NewJobsAPI(getRunCtx(ctx, d), c)
+			jobRun, err := jobsAPI.RunsGet(runID)
+			if isMissingRun(err, runID) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if jobRun.State.IsTerminal() {
+				// history of completed runs is kept
+				return nil
+			}
+			return jobsAPI.RunsCancel(runID, d.Timeout(schema.TimeoutDelete))
+		},
+	}.ToResource()
+}
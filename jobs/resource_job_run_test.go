@@ -0,0 +1,305 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceJobRunCreate_RunNow(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/run-now",
+				ExpectedRequest: RunParameters{
+					JobID: 789,
+					NotebookParams: map[string]string{
+						"schema": "v2",
+					},
+				},
+				Response: JobRun{
+					RunID: 890,
+				},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/jobs/runs/get?run_id=890",
+				ReuseRequest: true,
+				Response: JobRun{
+					RunID:      890,
+					RunPageURL: "https://example.com/#job/789/run/1",
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "SUCCESS",
+						StateMessage:   "",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get-output?run_id=890",
+				Response: RunOutput{
+					NotebookOutput: &NotebookOutput{
+						Result: "migrated 3 tables",
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL: `
+		job_id = 789
+		notebook_params = {
+			schema = "v2"
+		}
+		keepers = {
+			version = "1.2.3"
+		}
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "890", d.Id())
+	assert.Equal(t, "SUCCESS", d.Get("result_state"))
+	assert.Equal(t, "migrated 3 tables", d.Get("output"))
+	assert.Equal(t, "https://example.com/#job/789/run/1", d.Get("run_page_url"))
+}
+
+func TestResourceJobRunCreate_SubmitMultiTaskNoWait(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/runs/submit",
+				ExpectedRequest: SubmitRun{
+					RunName: "Migration",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Migrations/A",
+							},
+						},
+						{
+							TaskKey:           "b",
+							ExistingClusterID: "abc",
+							DependsOn: []TaskDependency{
+								{
+									TaskKey: "a",
+								},
+							},
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Migrations/B",
+							},
+						},
+					},
+				},
+				Response: JobRun{
+					RunID: 891,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/runs/get?run_id=891",
+				Response: JobRun{
+					RunID: 891,
+					State: RunState{
+						LifeCycleState: "RUNNING",
+					},
+					Tasks: []RunTask{
+						{
+							RunID:   892,
+							TaskKey: "a",
+						},
+						{
+							RunID:   893,
+							TaskKey: "b",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL: `
+		wait_for_completion = false
+		submit {
+			run_name = "Migration"
+			task {
+				task_key = "b"
+				existing_cluster_id = "abc"
+				depends_on {
+					task_key = "a"
+				}
+				notebook_task {
+					notebook_path = "/Migrations/B"
+				}
+			}
+			task {
+				task_key = "a"
+				existing_cluster_id = "abc"
+				notebook_task {
+					notebook_path = "/Migrations/A"
+				}
+			}
+		}
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "891", d.Id())
+	assert.Equal(t, "RUNNING", d.Get("life_cycle_state"))
+	assert.Equal(t, "", d.Get("output"))
+}
+
+func TestResourceJobRunCreate_Failed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/runs/submit",
+				ExpectedRequest: SubmitRun{
+					ExistingClusterID: "abc",
+					SparkPythonTask: &SparkPythonTask{
+						PythonFile: "dbfs:/migrate.py",
+					},
+				},
+				Response: JobRun{
+					RunID: 890,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=890",
+				Response: JobRun{
+					RunID:      890,
+					RunPageURL: "https://example.com/#job/1/run/1",
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "FAILED",
+						StateMessage:   "Task failed",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get-output?run_id=890",
+				Response: RunOutput{
+					Error: "ValueError: table exists",
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL: `
+		submit {
+			existing_cluster_id = "abc"
+			spark_python_task {
+				python_file = "dbfs:/migrate.py"
+			}
+		}
+		`,
+	}.ExpectError(t, "run 890 is FAILED: Task failed. See https://example.com/#job/1/run/1")
+}
+
+func TestResourceJobRunCreate_ConflictingParameters(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJobRun(),
+		HCL: `
+		notebook_params = {
+			a = "b"
+		}
+		submit {
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/A"
+			}
+		}
+		`,
+	}.ExpectError(t, "invalid config supplied. [notebook_params] "+
+		"Conflicting configuration arguments")
+}
+
+func TestResourceJobRunRead_Removed(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=890",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Run 890 does not exist.",
+				},
+			},
+		},
+		Read:     true,
+		Resource: ResourceJobRun(),
+		New:      true,
+		ID:       "890",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "890", d.Id(), "run should not be triggered again")
+}
+
+func TestResourceJobRunDelete_CancelsActive(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=890",
+				Response: JobRun{
+					State: RunState{
+						LifeCycleState: "RUNNING",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/runs/cancel",
+				ExpectedRequest: map[string]interface{}{
+					"run_id": 890,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=890",
+				Response: JobRun{
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "CANCELED",
+					},
+				},
+			},
+		},
+		Delete:   true,
+		Resource: ResourceJobRun(),
+		ID:       "890",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "890", d.Id())
+}
+
+func TestResourceJobRunDelete_KeepsCompleted(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=890",
+				Response: JobRun{
+					State: RunState{
+						LifeCycleState: "TERMINATED",
+						ResultState:    "SUCCESS",
+					},
+				},
+			},
+		},
+		Delete:   true,
+		Resource: ResourceJobRun(),
+		ID:       "890",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
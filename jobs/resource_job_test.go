@@ -207,6 +207,731 @@ func TestResourceJobCreate_MultiTask(t *testing.T) {
 	assert.Equal(t, "789", d.Id())
 }
 
+func TestResourceJobCreate_JobClusters(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Featurizer",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:       "a",
+							JobClusterKey: "shared",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/A",
+							},
+						},
+						{
+							TaskKey:       "b",
+							JobClusterKey: "shared",
+							DependsOn: []TaskDependency{
+								{
+									TaskKey: "a",
+								},
+							},
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/B",
+							},
+						},
+					},
+					JobClusters: []JobCluster{
+						{
+							JobClusterKey: "big",
+							NewCluster: &clusters.Cluster{
+								SparkVersion: "a",
+								NodeTypeID:   "b",
+								NumWorkers:   10,
+							},
+						},
+						{
+							JobClusterKey: "shared",
+							NewCluster: &clusters.Cluster{
+								SparkVersion: "a",
+								NodeTypeID:   "b",
+								NumWorkers:   2,
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					// good enough for mock
+					Settings: &JobSettings{
+						JobClusters: []JobCluster{
+							{
+								JobClusterKey: "shared",
+							},
+							{
+								JobClusterKey: "big",
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 2
+			}
+		}
+
+		job_cluster {
+			job_cluster_key = "big"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 10
+			}
+		}
+
+		task {
+			task_key = "b"
+			job_cluster_key = "shared"
+			depends_on {
+				task_key = "a"
+			}
+			notebook_task {
+				notebook_path = "/B"
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "shared"
+			notebook_task {
+				notebook_path = "/A"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "big", d.Get("job_cluster.0.job_cluster_key"))
+}
+
+func TestResourceJobCreate_UndefinedJobCluster(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				spark_version = "a"
+				node_type_id = "b"
+				num_workers = 2
+			}
+		}
+
+		task {
+			task_key = "a"
+			job_cluster_key = "sahred"
+			notebook_task {
+				notebook_path = "/A"
+			}
+		}`,
+	}.ExpectError(t, "task.0.job_cluster_key: task a refers to undefined job cluster sahred")
+}
+
+func TestResourceJobCreate_GitSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "From Git",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "notebooks/ingest",
+							},
+						},
+					},
+					GitSource: &GitSource{
+						Url:      "https://github.com/acme/etl",
+						Provider: "gitHub",
+						Tag:      "v1.2.3",
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "From Git",
+						GitSource: &GitSource{
+							Url:      "https://github.com/acme/etl",
+							Provider: "gitHub",
+							Tag:      "v1.2.3",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "From Git"
+
+		git_source {
+			url = "https://github.com/acme/etl"
+			tag = "v1.2.3"
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "notebooks/ingest"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "gitHub", d.Get("git_source.0.provider"))
+}
+
+func TestResourceJobCreate_GitSourceConflictingRefs(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		git_source {
+			url = "https://github.com/acme/etl"
+			branch = "main"
+			commit = "abcdef"
+		}`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[git_source.#.branch] Invalid combination of arguments. "+
+		"[git_source.#.commit] Invalid combination of arguments. "+
+		"[git_source.#.tag] Invalid combination of arguments")
+}
+
+func TestResourceJobCreate_GitSourceUnknownProvider(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		git_source {
+			url = "https://git.acme.com/etl"
+			branch = "main"
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "notebooks/ingest"
+			}
+		}`,
+	}.ExpectError(t, "git_source.0.provider isn't specified and we can't "+
+		"detect provider from URL https://git.acme.com/etl")
+}
+
+func TestResourceJobCreate_SqlAndDbtTasks(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Analytics",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							DbtTask: &DbtTask{
+								Commands:         []string{"dbt deps", "dbt run"},
+								ProjectDirectory: "jaffle_shop",
+								Schema:           "analytics",
+								WarehouseID:      "def",
+							},
+						},
+						{
+							TaskKey: "b",
+							DependsOn: []TaskDependency{
+								{
+									TaskKey: "a",
+								},
+							},
+							SqlTask: &SqlTask{
+								Query: &SqlQueryTask{
+									QueryID: "ghi",
+								},
+								WarehouseID: "def",
+								Parameters: map[string]string{
+									"day": "today",
+								},
+							},
+						},
+					},
+					GitSource: &GitSource{
+						Url:      "https://github.com/acme/jaffle_shop",
+						Provider: "gitHub",
+						Branch:   "main",
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Analytics",
+						Tasks: []JobTaskSettings{
+							{
+								TaskKey: "b",
+								SqlTask: &SqlTask{
+									Query: &SqlQueryTask{
+										QueryID: "ghi",
+									},
+									WarehouseID: "def",
+								},
+							},
+							{
+								TaskKey:           "a",
+								ExistingClusterID: "abc",
+								DbtTask: &DbtTask{
+									Commands: []string{"dbt deps", "dbt run"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Analytics"
+
+		git_source {
+			url = "https://github.com/acme/jaffle_shop"
+			branch = "main"
+		}
+
+		task {
+			task_key = "b"
+			depends_on {
+				task_key = "a"
+			}
+			sql_task {
+				query {
+					query_id = "ghi"
+				}
+				warehouse_id = "def"
+				parameters = {
+					day = "today"
+				}
+			}
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			dbt_task {
+				commands = ["dbt deps", "dbt run"]
+				project_directory = "jaffle_shop"
+				schema = "analytics"
+				warehouse_id = "def"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "ghi", d.Get("task.1.sql_task.0.query.0.query_id"))
+	assert.Equal(t, "dbt run", d.Get("task.0.dbt_task.0.commands.1"))
+}
+
+func TestResourceJobCreate_WebhookNotifications(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name: "Monitored",
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/Ingest",
+							},
+							WebhookNotifications: &WebhookNotifications{
+								OnStart: []Webhook{
+									{ID: "slack-1"},
+								},
+							},
+						},
+					},
+					MaxConcurrentRuns: 1,
+					WebhookNotifications: &WebhookNotifications{
+						OnSuccess: []Webhook{
+							{ID: "slack-1"},
+						},
+						OnFailure: []Webhook{
+							{ID: "pagerduty-1"},
+							{ID: "slack-1"},
+						},
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name: "Monitored",
+						WebhookNotifications: &WebhookNotifications{
+							OnSuccess: []Webhook{
+								{ID: "slack-1"},
+							},
+							OnFailure: []Webhook{
+								{ID: "pagerduty-1"},
+								{ID: "slack-1"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Monitored"
+
+		webhook_notifications {
+			on_success {
+				id = "slack-1"
+			}
+			on_failure {
+				id = "pagerduty-1"
+			}
+			on_failure {
+				id = "slack-1"
+			}
+		}
+
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Ingest"
+			}
+			webhook_notifications {
+				on_start {
+					id = "slack-1"
+				}
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "pagerduty-1", d.Get("webhook_notifications.0.on_failure.0.id"))
+}
+
+func TestResourceJobCreate_WebhookNotificationsSingleTask(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Monitored",
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Ingest",
+					},
+					MaxConcurrentRuns: 1,
+					WebhookNotifications: &WebhookNotifications{
+						OnFailure: []Webhook{
+							{ID: "pagerduty-1"},
+						},
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Monitored",
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Ingest",
+						},
+						WebhookNotifications: &WebhookNotifications{
+							OnFailure: []Webhook{
+								{ID: "pagerduty-1"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Monitored"
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		webhook_notifications {
+			on_failure {
+				id = "pagerduty-1"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "pagerduty-1", d.Get("webhook_notifications.0.on_failure.0.id"))
+}
+
+func TestResourceJobCreate_TooManyWebhooks(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		webhook_notifications {
+			on_failure {
+				id = "a"
+			}
+			on_failure {
+				id = "b"
+			}
+			on_failure {
+				id = "c"
+			}
+			on_failure {
+				id = "d"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. [webhook_notifications.#.on_failure] "+
+		"Too many list items")
+}
+
+func TestResourceJobCreate_RunAsAndOwner(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Nightly",
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Ingest",
+					},
+					RunAs: &RunAs{
+						ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+					},
+					MaxConcurrentRuns: 1,
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/permissions/jobs/789",
+				ExpectedRequest: jobOwnerChangeList{
+					AccessControlList: []jobOwnerChange{
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							PermissionLevel:      "IS_OWNER",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Nightly",
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Ingest",
+						},
+						RunAs: &RunAs{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/789",
+				Response: jobPermissions{
+					AccessControlList: []jobAccessControl{
+						{
+							UserName: "creator@example.com",
+							AllPermissions: []jobPermission{
+								{PermissionLevel: "CAN_MANAGE"},
+							},
+						},
+						{
+							ServicePrincipalName: "00000000-0000-0000-0000-000000000001",
+							AllPermissions: []jobPermission{
+								{PermissionLevel: "IS_OWNER"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Nightly"
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		run_as {
+			service_principal_name = "00000000-0000-0000-0000-000000000001"
+		}
+		owner = "00000000-0000-0000-0000-000000000001"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", d.Get("owner"))
+}
+
+func TestResourceJobCreate_RunAsConflict(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		run_as {
+			user_name = "jane@example.com"
+			service_principal_name = "00000000-0000-0000-0000-000000000001"
+		}`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[run_as.#.service_principal_name] Invalid combination of arguments. "+
+		"[run_as.#.user_name] Invalid combination of arguments")
+}
+
+func TestResourceJobRead_OwnerChanged(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Nightly",
+						ExistingClusterID: "abc",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/789",
+				Response: jobPermissions{
+					AccessControlList: []jobAccessControl{
+						{
+							UserName: "john@example.com",
+							AllPermissions: []jobPermission{
+								{PermissionLevel: "IS_OWNER"},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:     true,
+		Resource: ResourceJob(),
+		ID:       "789",
+		State: map[string]interface{}{
+			"owner": "jane@example.com",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "john@example.com", d.Get("owner"))
+}
+
+func TestResourceJobCreate_InvalidSchedule(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		schedule {
+			quartz_cron_expression = "0 0 25 * * ?"
+			timezone_id = "Europe/Amsterdam"
+		}`,
+	}.ExpectError(t, "invalid config supplied. [schedule.#.quartz_cron_expression] invalid value "+
+		"for schedule.0.quartz_cron_expression (hours 25 is not between 0 and 23)")
+}
+
+func TestResourceJobCreate_InvalidTimezone(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Ingest"
+		}
+		schedule {
+			quartz_cron_expression = "0 0 6 * * ?"
+			timezone_id = "Europe/Atlantis"
+		}`,
+	}.ExpectError(t, "invalid config supplied. [schedule.#.timezone_id] invalid value for "+
+		"schedule.0.timezone_id (Europe/Atlantis is not a known IANA timezone, like Europe/Amsterdam or UTC)")
+}
+
 func TestResourceJobCreate_AlwaysRunning(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -287,6 +1012,58 @@ func TestResourceJobCreate_AlwaysRunning_Conflict(t *testing.T) {
 	}.ExpectError(t, "`always_running` must be specified only with `max_concurrent_runs = 1`")
 }
 
+func TestResourceJobCreate_PolicyViolations(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=abc",
+				ReuseRequest: true,
+				Response: clusters.ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Jobs only",
+					Definition: `{
+						"cluster_type": {"type": "fixed", "value": "job"},
+						"spark_conf.*": {"type": "forbidden"}
+					}`,
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+		task {
+			task_key = "a"
+			new_cluster {
+				policy_id = "abc"
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 1
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}
+		task {
+			task_key = "b"
+			new_cluster {
+				policy_id = "abc"
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+				num_workers = 1
+				spark_conf = {
+					"spark.speculation" = "true"
+				}
+			}
+			notebook_task {
+				notebook_path = "/Stuff"
+			}
+		}`,
+	}.ExpectError(t, "task b invalid: cluster doesn't conform to Jobs only policy:\n"+
+		"task.1.new_cluster.0.spark_conf.spark.speculation: is forbidden")
+}
+
 func TestResourceJobCreateSingleNode(t *testing.T) {
 	cluster := clusters.Cluster{
 		NumWorkers: 0, SparkVersion: "7.3.x-scala2.12", NodeTypeID: "Standard_DS3_v2",
@@ -697,6 +1474,76 @@ func TestResourceJobUpdate_Tasks(t *testing.T) {
 	}.ApplyNoError(t)
 }
 
+func TestResourceJobUpdate_Owner(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Nightly",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+					},
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/permissions/jobs/789",
+				ExpectedRequest: jobOwnerChangeList{
+					AccessControlList: []jobOwnerChange{
+						{
+							UserName:        "john@example.com",
+							PermissionLevel: "IS_OWNER",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Nightly",
+						ExistingClusterID: "abc",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/jobs/789",
+				Response: jobPermissions{
+					AccessControlList: []jobAccessControl{
+						{
+							UserName: "John@example.com",
+							AllPermissions: []jobPermission{
+								{PermissionLevel: "IS_OWNER"},
+							},
+						},
+					},
+				},
+			},
+		},
+		ID:     "789",
+		Update: true,
+		InstanceState: map[string]string{
+			"name":                "Nightly",
+			"existing_cluster_id": "abc",
+			"owner":               "jane@example.com",
+		},
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Nightly"
+		existing_cluster_id = "abc"
+		owner = "john@example.com"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "John@example.com", d.Get("owner"))
+}
+
 func TestResourceJobUpdate_Restart(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -974,7 +1821,7 @@ func TestJobsAPIList(t *testing.T) {
 	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/jobs/list",
+			Resource: "/api/2.0/jobs/list?limit=25",
 			Response: JobList{
 				Jobs: []Job{
 					{
@@ -0,0 +1,298 @@
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// legacyTaskSettings returns paths of Jobs API 2.0 settings, that cannot be combined with tasks
+func (js *JobSettings) legacyTaskSettings() (paths []string) {
+	for path, isSet := range map[string]bool{
+		"existing_cluster_id": js.ExistingClusterID != "",
+		"new_cluster":         js.NewCluster != nil,
+		"notebook_task":       js.NotebookTask != nil,
+		"spark_jar_task":      js.SparkJarTask != nil,
+		"spark_python_task":   js.SparkPythonTask != nil,
+		"spark_submit_task":   js.SparkSubmitTask != nil,
+		"pipeline_task":       js.PipelineTask != nil,
+		"python_wheel_task":   js.PythonWheelTask != nil,
+		"library":             len(js.Libraries) > 0,
+	} {
+		if isSet {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return
+}
+
+// legacyRetrySettings returns paths of job-level retry settings, that have no effect on tasks
+func (js *JobSettings) legacyRetrySettings() (paths []string) {
+	if js.MaxRetries != 0 {
+		paths = append(paths, "max_retries")
+	}
+	if js.MinRetryIntervalMillis != 0 {
+		paths = append(paths, "min_retry_interval_millis")
+	}
+	if js.RetryOnTimeout {
+		paths = append(paths, "retry_on_timeout")
+	}
+	return
+}
+
+// taskWarnings returns problems with tasks, that don't prevent the job from running,
+// but are most likely configuration mistakes
+func (js *JobSettings) taskWarnings() (warnings []string) {
+	if len(js.Tasks) == 0 {
+		return
+	}
+	if legacy := js.legacyRetrySettings(); len(legacy) > 0 {
+		warnings = append(warnings, fmt.Sprintf("%s have no effect with task blocks and are "+
+			"deprecated, please move them to each of the tasks", strings.Join(legacy, ", ")))
+	}
+	used := map[string]bool{}
+	for _, task := range js.Tasks {
+		used[task.JobClusterKey] = true
+	}
+	for _, jc := range js.JobClusters {
+		if !used[jc.JobClusterKey] {
+			warnings = append(warnings, fmt.Sprintf("job cluster %s is not used by any task",
+				jc.JobClusterKey))
+		}
+	}
+	if len(js.Tasks) < 2 {
+		return
+	}
+	connected := map[string]bool{}
+	for _, task := range js.Tasks {
+		for _, dep := range task.DependsOn {
+			connected[task.TaskKey] = true
+			connected[dep.TaskKey] = true
+		}
+	}
+	for i, task := range js.Tasks {
+		if task.TaskKey != "" && !connected[task.TaskKey] {
+			// parallel tasks are legitimate, though often it's a forgotten depends_on
+			warnings = append(warnings, fmt.Sprintf("task %s (task.%d) is not connected "+
+				"to any other task", task.TaskKey, i))
+		}
+	}
+	return
+}
+
+// validateTaskCluster checks that task runs either on existing, on a new or on a job cluster.
+// Pipeline tasks run on clusters of the pipeline and SQL tasks run on SQL endpoints.
+func validateTaskCluster(i int, task JobTaskSettings, isKnown func(string) bool) error {
+	if task.PipelineTask != nil || task.SqlTask != nil {
+		return nil
+	}
+	clusterTypes := 0
+	if task.ExistingClusterID != "" || !isKnown(fmt.Sprintf("task.%d.existing_cluster_id", i)) {
+		clusterTypes++
+	}
+	if task.NewCluster != nil {
+		clusterTypes++
+	}
+	if task.JobClusterKey != "" || !isKnown(fmt.Sprintf("task.%d.job_cluster_key", i)) {
+		clusterTypes++
+	}
+	if clusterTypes > 1 {
+		return fmt.Errorf("task %s (task.%d) must have only one of existing_cluster_id, "+
+			"new_cluster or job_cluster_key", task.TaskKey, i)
+	}
+	if clusterTypes == 0 {
+		return fmt.Errorf("task %s (task.%d) must have one of existing_cluster_id, "+
+			"new_cluster or job_cluster_key", task.TaskKey, i)
+	}
+	return nil
+}
+
+// validateSqlTask checks that SQL task refers to exactly one of query, dashboard or alert
+func validateSqlTask(i int, task JobTaskSettings) error {
+	if task.SqlTask == nil {
+		return nil
+	}
+	refs := 0
+	for _, isSet := range []bool{
+		task.SqlTask.Query != nil,
+		task.SqlTask.Dashboard != nil,
+		task.SqlTask.Alert != nil,
+	} {
+		if isSet {
+			refs++
+		}
+	}
+	if refs != 1 {
+		return fmt.Errorf("task %s (task.%d) must have exactly one of query, dashboard "+
+			"or alert in sql_task", task.TaskKey, i)
+	}
+	return nil
+}
+
+// validateDbtTask checks that dbt task runs only dbt commands from the project in git_source
+func (js *JobSettings) validateDbtTask(i int, task JobTaskSettings, isKnown func(string) bool) error {
+	if task.DbtTask == nil {
+		return nil
+	}
+	if js.GitSource == nil {
+		return fmt.Errorf("task %s (task.%d) requires git_source with the dbt project",
+			task.TaskKey, i)
+	}
+	for j, command := range task.DbtTask.Commands {
+		if !isKnown(fmt.Sprintf("task.%d.dbt_task.0.commands.%d", i, j)) {
+			continue
+		}
+		if !strings.HasPrefix(command, "dbt ") {
+			return fmt.Errorf("task.%d.dbt_task.0.commands.%d: command must start with "+
+				"`dbt `, but got %s", i, j, command)
+		}
+	}
+	return nil
+}
+
+// validateJobClusters checks that job cluster keys are unique and tasks refer only to
+// defined job clusters. Keys are not checked, if any of them is not known yet.
+func (js *JobSettings) validateJobClusters(isKnown func(string) bool) error {
+	index := map[string]int{}
+	for i, jc := range js.JobClusters {
+		if !isKnown(fmt.Sprintf("job_cluster.%d.job_cluster_key", i)) {
+			return nil
+		}
+		if j, ok := index[jc.JobClusterKey]; ok {
+			return fmt.Errorf("job_cluster.%d.job_cluster_key: duplicate job cluster key %s, "+
+				"also used by job_cluster.%d", i, jc.JobClusterKey, j)
+		}
+		index[jc.JobClusterKey] = i
+	}
+	for i, task := range js.Tasks {
+		if task.JobClusterKey == "" {
+			continue
+		}
+		if _, ok := index[task.JobClusterKey]; !ok {
+			return fmt.Errorf("task.%d.job_cluster_key: task %s refers to undefined "+
+				"job cluster %s", i, task.TaskKey, task.JobClusterKey)
+		}
+	}
+	return nil
+}
+
+// findDependencyCycle returns task keys that form a cycle, where the last key is the same as the first one
+func findDependencyCycle(keys []string, dependsOn map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		state[key] = visiting
+		path = append(path, key)
+		for _, dep := range dependsOn[key] {
+			switch state[dep] {
+			case visiting:
+				for i, k := range path {
+					if k == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+	for _, key := range keys {
+		if state[key] != unvisited {
+			continue
+		}
+		if cycle := visit(key); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validateTasks checks the graph of tasks at plan time, which is otherwise done only by the server.
+// Graph is not checked, if any of task keys is not known yet.
+func (js *JobSettings) validateTasks(isKnown func(string) bool) error {
+	if len(js.Tasks) == 0 {
+		if len(js.JobClusters) > 0 {
+			return fmt.Errorf("job_cluster blocks can be used only with task blocks")
+		}
+		if js.GitSource != nil {
+			return fmt.Errorf("git_source can be used only with task blocks")
+		}
+		return nil
+	}
+	if legacy := js.legacyTaskSettings(); len(legacy) > 0 {
+		return fmt.Errorf("%s cannot be used with task blocks, "+
+			"please move them to each of the tasks", strings.Join(legacy, ", "))
+	}
+	if err := js.validateJobClusters(isKnown); err != nil {
+		return err
+	}
+	keys := []string{}
+	index := map[string]int{}
+	dependsOn := map[string][]string{}
+	known := true
+	for i, task := range js.Tasks {
+		if err := validateTaskCluster(i, task, isKnown); err != nil {
+			return err
+		}
+		if err := validateSqlTask(i, task); err != nil {
+			return err
+		}
+		if err := js.validateDbtTask(i, task, isKnown); err != nil {
+			return err
+		}
+		if !isKnown(fmt.Sprintf("task.%d.task_key", i)) {
+			known = false
+			continue
+		}
+		if task.TaskKey == "" && len(js.Tasks) > 1 {
+			return fmt.Errorf("task.%d.task_key is required for jobs with multiple tasks", i)
+		}
+		if j, ok := index[task.TaskKey]; ok {
+			return fmt.Errorf("task.%d.task_key: duplicate task key %s, also used by task.%d",
+				i, task.TaskKey, j)
+		}
+		index[task.TaskKey] = i
+		keys = append(keys, task.TaskKey)
+		for j, dep := range task.DependsOn {
+			if !isKnown(fmt.Sprintf("task.%d.depends_on.%d.task_key", i, j)) {
+				known = false
+				continue
+			}
+			dependsOn[task.TaskKey] = append(dependsOn[task.TaskKey], dep.TaskKey)
+		}
+	}
+	if !known {
+		return nil
+	}
+	for _, key := range keys {
+		i := index[key]
+		for j, dep := range js.Tasks[i].DependsOn {
+			if _, ok := index[dep.TaskKey]; !ok {
+				return fmt.Errorf("task.%d.depends_on.%d.task_key: task %s depends on "+
+					"undefined task %s", i, j, key, dep.TaskKey)
+			}
+		}
+	}
+	sort.Strings(keys)
+	if cycle := findDependencyCycle(keys, dependsOn); cycle != nil {
+		return fmt.Errorf("task.%d.depends_on: tasks have a dependency cycle: %s",
+			index[cycle[0]], strings.Join(cycle, " -> "))
+	}
+	for _, warning := range js.taskWarnings() {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
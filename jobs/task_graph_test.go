@@ -0,0 +1,354 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func alwaysKnown(string) bool {
+	return true
+}
+
+func task(key string, dependsOn ...string) JobTaskSettings {
+	t := JobTaskSettings{
+		TaskKey:           key,
+		ExistingClusterID: "abc",
+		NotebookTask: &NotebookTask{
+			NotebookPath: "/" + key,
+		},
+	}
+	for _, dep := range dependsOn {
+		t.DependsOn = append(t.DependsOn, TaskDependency{TaskKey: dep})
+	}
+	return t
+}
+
+func TestValidateTasks(t *testing.T) {
+	bothClusters := task("b", "a")
+	bothClusters.NewCluster = &clusters.Cluster{}
+	noCluster := task("b", "a")
+	noCluster.ExistingClusterID = ""
+	onJobCluster := task("b", "a")
+	onJobCluster.ExistingClusterID = ""
+	onJobCluster.JobClusterKey = "shared"
+	pipeline := JobTaskSettings{
+		TaskKey: "b",
+		PipelineTask: &PipelineTask{
+			PipelineID: "def",
+		},
+	}
+	sqlQuery := JobTaskSettings{
+		TaskKey: "b",
+		SqlTask: &SqlTask{
+			Query:       &SqlQueryTask{QueryID: "q"},
+			WarehouseID: "w",
+		},
+	}
+	sqlWithoutRefs := JobTaskSettings{
+		TaskKey: "b",
+		SqlTask: &SqlTask{
+			WarehouseID: "w",
+		},
+	}
+	sqlWithTwoRefs := JobTaskSettings{
+		TaskKey: "b",
+		SqlTask: &SqlTask{
+			Query:       &SqlQueryTask{QueryID: "q"},
+			Alert:       &SqlAlertTask{AlertID: "x"},
+			WarehouseID: "w",
+		},
+	}
+	dbt := task("b", "a")
+	dbt.NotebookTask = nil
+	dbt.DbtTask = &DbtTask{
+		Commands: []string{"dbt deps", "dbt run"},
+	}
+	notDbt := task("b", "a")
+	notDbt.NotebookTask = nil
+	notDbt.DbtTask = &DbtTask{
+		Commands: []string{"dbt deps", "rm -rf /"},
+	}
+	gitSource := &GitSource{
+		Url:    "https://github.com/example/jaffle_shop",
+		Branch: "main",
+	}
+	for name, tc := range map[string]struct {
+		js  JobSettings
+		err string
+	}{
+		"no tasks": {
+			js: JobSettings{ExistingClusterID: "abc"},
+		},
+		"valid": {
+			js: JobSettings{Tasks: []JobTaskSettings{
+				task("c", "a", "b"), task("a"), task("b", "a")}},
+		},
+		"pipeline without cluster": {
+			js: JobSettings{Tasks: []JobTaskSettings{task("a"), pipeline}},
+		},
+		"legacy settings": {
+			js: JobSettings{
+				ExistingClusterID: "abc",
+				MaxRetries:        1,
+				Tasks:             []JobTaskSettings{task("a")},
+			},
+			err: "existing_cluster_id cannot be used with task blocks, " +
+				"please move them to each of the tasks",
+		},
+		"legacy retry settings": {
+			js: JobSettings{
+				MaxRetries:     1,
+				RetryOnTimeout: true,
+				Tasks:          []JobTaskSettings{task("a")},
+			},
+		},
+		"duplicate key": {
+			js: JobSettings{Tasks: []JobTaskSettings{
+				task("a"), task("b", "a"), task("a")}},
+			err: "task.2.task_key: duplicate task key a, also used by task.0",
+		},
+		"missing key": {
+			js:  JobSettings{Tasks: []JobTaskSettings{task("a"), task("")}},
+			err: "task.1.task_key is required for jobs with multiple tasks",
+		},
+		"undefined dependency": {
+			js: JobSettings{Tasks: []JobTaskSettings{
+				task("a"), task("b", "a", "x")}},
+			err: "task.1.depends_on.1.task_key: task b depends on undefined task x",
+		},
+		"cycle": {
+			js: JobSettings{Tasks: []JobTaskSettings{
+				task("a"), task("b", "a", "d"), task("c", "b"), task("d", "c")}},
+			err: "task.1.depends_on: tasks have a dependency cycle: b -> d -> c -> b",
+		},
+		"self dependency": {
+			js:  JobSettings{Tasks: []JobTaskSettings{task("a", "a")}},
+			err: "task.0.depends_on: tasks have a dependency cycle: a -> a",
+		},
+		"both clusters": {
+			js: JobSettings{Tasks: []JobTaskSettings{task("a"), bothClusters}},
+			err: "task b (task.1) must have only one of existing_cluster_id, " +
+				"new_cluster or job_cluster_key",
+		},
+		"no cluster": {
+			js: JobSettings{Tasks: []JobTaskSettings{task("a"), noCluster}},
+			err: "task b (task.1) must have one of existing_cluster_id, " +
+				"new_cluster or job_cluster_key",
+		},
+		"job clusters": {
+			js: JobSettings{
+				JobClusters: []JobCluster{
+					{JobClusterKey: "shared", NewCluster: &clusters.Cluster{}},
+				},
+				Tasks: []JobTaskSettings{task("a"), onJobCluster},
+			},
+		},
+		"job clusters without tasks": {
+			js: JobSettings{
+				JobClusters: []JobCluster{
+					{JobClusterKey: "shared", NewCluster: &clusters.Cluster{}},
+				},
+			},
+			err: "job_cluster blocks can be used only with task blocks",
+		},
+		"duplicate job cluster": {
+			js: JobSettings{
+				JobClusters: []JobCluster{
+					{JobClusterKey: "shared", NewCluster: &clusters.Cluster{}},
+					{JobClusterKey: "shared", NewCluster: &clusters.Cluster{}},
+				},
+				Tasks: []JobTaskSettings{task("a"), onJobCluster},
+			},
+			err: "job_cluster.1.job_cluster_key: duplicate job cluster key shared, " +
+				"also used by job_cluster.0",
+		},
+		"undefined job cluster": {
+			js: JobSettings{
+				JobClusters: []JobCluster{
+					{JobClusterKey: "other", NewCluster: &clusters.Cluster{}},
+				},
+				Tasks: []JobTaskSettings{task("a"), onJobCluster},
+			},
+			err: "task.1.job_cluster_key: task b refers to undefined job cluster shared",
+		},
+		"sql task without cluster": {
+			js: JobSettings{Tasks: []JobTaskSettings{task("a"), sqlQuery}},
+		},
+		"sql task without query": {
+			js:  JobSettings{Tasks: []JobTaskSettings{task("a"), sqlWithoutRefs}},
+			err: "task b (task.1) must have exactly one of query, dashboard or alert in sql_task",
+		},
+		"sql task with query and alert": {
+			js:  JobSettings{Tasks: []JobTaskSettings{task("a"), sqlWithTwoRefs}},
+			err: "task b (task.1) must have exactly one of query, dashboard or alert in sql_task",
+		},
+		"dbt task": {
+			js: JobSettings{
+				GitSource: gitSource,
+				Tasks:     []JobTaskSettings{task("a"), dbt},
+			},
+		},
+		"dbt task without git source": {
+			js:  JobSettings{Tasks: []JobTaskSettings{task("a"), dbt}},
+			err: "task b (task.1) requires git_source with the dbt project",
+		},
+		"dbt task with other command": {
+			js: JobSettings{
+				GitSource: gitSource,
+				Tasks:     []JobTaskSettings{task("a"), notDbt},
+			},
+			err: "task.1.dbt_task.0.commands.1: command must start with `dbt `, but got rm -rf /",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.js.validateTasks(alwaysKnown)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestTaskWarnings(t *testing.T) {
+	onJobCluster := task("b", "a")
+	onJobCluster.ExistingClusterID = ""
+	onJobCluster.JobClusterKey = "shared"
+	assert.Len(t, (&JobSettings{MaxRetries: 1}).taskWarnings(), 0)
+	assert.Len(t, (&JobSettings{Tasks: []JobTaskSettings{task("a")}}).taskWarnings(), 0)
+	assert.Equal(t, []string{
+		"max_retries, min_retry_interval_millis, retry_on_timeout have no effect with " +
+			"task blocks and are deprecated, please move them to each of the tasks",
+		"job cluster unused is not used by any task",
+		"task c (task.2) is not connected to any other task",
+	}, (&JobSettings{
+		MaxRetries:             1,
+		MinRetryIntervalMillis: 1000,
+		RetryOnTimeout:         true,
+		JobClusters: []JobCluster{
+			{JobClusterKey: "shared", NewCluster: &clusters.Cluster{}},
+			{JobClusterKey: "unused", NewCluster: &clusters.Cluster{}},
+		},
+		Tasks: []JobTaskSettings{task("a"), onJobCluster, task("c")},
+	}).taskWarnings())
+}
+
+func TestResourceJobUpdate_LegacyRetryWarning(t *testing.T) {
+	qa.HTTPFixturesApply(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.1/jobs/reset",
+			ExpectedRequest: UpdateJobRequest{
+				JobID: 789,
+				NewSettings: &JobSettings{
+					Name:              "Featurizer",
+					MaxRetries:        3,
+					MaxConcurrentRuns: 1,
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/a",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.1/jobs/get?job_id=789",
+			Response: Job{
+				JobID: 789,
+				Settings: &JobSettings{
+					Name:       "Featurizer",
+					MaxRetries: 3,
+					Tasks: []JobTaskSettings{
+						{
+							TaskKey:           "a",
+							ExistingClusterID: "abc",
+							NotebookTask: &NotebookTask{
+								NotebookPath: "/a",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, func(ctx context.Context, client *common.DatabricksClient) {
+		r := ResourceJob()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name":        "Featurizer",
+			"max_retries": 3,
+			"task": []interface{}{
+				map[string]interface{}{
+					"task_key":            "a",
+					"existing_cluster_id": "abc",
+					"notebook_task": []interface{}{
+						map[string]interface{}{
+							"notebook_path": "/a",
+						},
+					},
+				},
+			},
+		})
+		d.SetId("789")
+		diags := r.UpdateContext(ctx, d, client)
+		assert.False(t, diags.HasError(), diags)
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "max_retries have no effect with task blocks and are deprecated, "+
+			"please move them to each of the tasks", diags[0].Summary)
+	})
+}
+
+func TestValidateTasks_UnknownValues(t *testing.T) {
+	noCluster := task("b", "a")
+	noCluster.ExistingClusterID = ""
+	js := JobSettings{Tasks: []JobTaskSettings{
+		task("a", "x"), noCluster}}
+	err := js.validateTasks(func(path string) bool {
+		return path != "task.0.depends_on.0.task_key" &&
+			path != "task.1.existing_cluster_id"
+	})
+	assert.NoError(t, err)
+}
+
+func TestResourceJobCreate_TaskCycle(t *testing.T) {
+	qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `
+		name = "Featurizer"
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			depends_on {
+				task_key = "b"
+			}
+			notebook_task {
+				notebook_path = "/A"
+			}
+		}
+		task {
+			task_key = "b"
+			existing_cluster_id = "abc"
+			depends_on {
+				task_key = "a"
+			}
+			notebook_task {
+				notebook_path = "/B"
+			}
+		}`,
+	}.ExpectError(t, "task.0.depends_on: tasks have a dependency cycle: a -> b -> a")
+}
@@ -0,0 +1,23 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+)
+
+// ClusterPolicy defines cluster policy. It's kept here for compatibility,
+// as cluster policies API is now used to check clusters during plan.
+type ClusterPolicy = clusters.ClusterPolicy
+
+// ClusterPolicyCreate is the endity used for request
+type ClusterPolicyCreate = clusters.ClusterPolicyCreate
+
+// ClusterPoliciesAPI struct for cluster policies API
+type ClusterPoliciesAPI = clusters.ClusterPoliciesAPI
+
+// NewClusterPoliciesAPI creates ClusterPoliciesAPI instance from provider meta
+// Creation and editing is available to admins only.
+func NewClusterPoliciesAPI(ctx context.Context, m interface{}) ClusterPoliciesAPI {
+	return clusters.NewClusterPoliciesAPI(ctx, m)
+}
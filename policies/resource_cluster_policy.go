@@ -2,83 +2,181 @@ package policies
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
 
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
 	"github.com/databrickslabs/terraform-provider-databricks/common"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// ClusterPolicy defines cluster policy
-type ClusterPolicy struct {
-	PolicyID           string `json:"policy_id,omitempty"`
-	Name               string `json:"name"`
-	Definition         string `json:"definition"`
-	CreatedAtTimeStamp int64  `json:"created_at_timestamp"`
-}
-
-// ClusterPolicyCreate is the endity used for request
-type ClusterPolicyCreate struct {
-	Name       string `json:"name"`
-	Definition string `json:"definition"`
+func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
+	clusterPolicy := new(ClusterPolicy)
+	clusterPolicy.PolicyID = d.Id()
+	if name, ok := d.GetOk("name"); ok {
+		clusterPolicy.Name = name.(string)
+	}
+	if data, ok := d.GetOk("definition"); ok {
+		clusterPolicy.Definition = data.(string)
+	}
+	if rules, ok := d.GetOk("rule"); ok {
+		definition, err := rulesToDefinition(rules.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		clusterPolicy.Definition = definition
+	}
+	return clusterPolicy, nil
 }
 
-// NewClusterPoliciesAPI creates ClusterPoliciesAPI instance from provider meta
-// Creation and editing is available to admins only.
-func NewClusterPoliciesAPI(ctx context.Context, m interface{}) ClusterPoliciesAPI {
-	return ClusterPoliciesAPI{m.(*common.DatabricksClient), ctx}
+func parseRuleValue(path, field string, raw map[string]interface{}) (interface{}, error) {
+	value := raw[field].(string)
+	if value == "" {
+		return nil, nil
+	}
+	return clusters.ParsePolicyRuleValue(path, value)
 }
 
-// ClusterPoliciesAPI struct for cluster policies API
-type ClusterPoliciesAPI struct {
-	client  *common.DatabricksClient
-	context context.Context
+func parseRuleNumber(field string, raw map[string]interface{}) (*float64, error) {
+	value := raw[field].(string)
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a number: %s", field, value)
+	}
+	return &f, nil
 }
 
-type policyIDWrapper struct {
-	PolicyID string `json:"policy_id,omitempty" url:"policy_id,omitempty"`
+func ruleFromData(raw map[string]interface{}) (rule clusters.ClusterPolicyRule, err error) {
+	path := raw["path"].(string)
+	if err = clusters.ValidatePolicyPath(path); err != nil {
+		return
+	}
+	rule.Type = raw["type"].(string)
+	rule.Pattern = raw["pattern"].(string)
+	rule.IsOptional = raw["is_optional"].(bool)
+	rule.Hidden = raw["hidden"].(bool)
+	if rule.Value, err = parseRuleValue(path, "value", raw); err != nil {
+		return
+	}
+	if rule.DefaultValue, err = parseRuleValue(path, "default_value", raw); err != nil {
+		return
+	}
+	for _, v := range raw["values"].([]interface{}) {
+		value, err := clusters.ParsePolicyRuleValue(path, v.(string))
+		if err != nil {
+			return rule, err
+		}
+		rule.Values = append(rule.Values, value)
+	}
+	if rule.MinValue, err = parseRuleNumber("min_value", raw); err != nil {
+		return
+	}
+	if rule.MaxValue, err = parseRuleNumber("max_value", raw); err != nil {
+		return
+	}
+	err = rule.Validate()
+	return
 }
 
-// Create creates new cluster policy and sets PolicyID
-func (a ClusterPoliciesAPI) Create(clusterPolicy *ClusterPolicy) error {
-	var policyIDResponse = policyIDWrapper{}
-	err := a.client.Post(a.context, "/policies/clusters/create", clusterPolicy, &policyIDResponse)
-	if err != nil {
-		return err
+// rulesToDefinition converts rule blocks to the policy definition JSON document
+func rulesToDefinition(rules []interface{}) (string, error) {
+	definition := clusters.ClusterPolicyDefinition{}
+	for _, r := range rules {
+		raw := r.(map[string]interface{})
+		path := raw["path"].(string)
+		if _, ok := definition[path]; ok {
+			return "", fmt.Errorf("duplicate rule for %s", path)
+		}
+		rule, err := ruleFromData(raw)
+		if err != nil {
+			return "", fmt.Errorf("rule for %s: %w", path, err)
+		}
+		definition[path] = rule
 	}
-	clusterPolicy.PolicyID = policyIDResponse.PolicyID
-	return nil
+	// keys of the map are sorted, so the document is stable
+	data, err := json.Marshal(definition)
+	return string(data), err
 }
 
-// Edit will update an existing policy.
-// This may make some clusters governed by this policy invalid.
-// For such clusters the next cluster edit must provide a confirming configuration,
-// but otherwise they can continue to run.
-func (a ClusterPoliciesAPI) Edit(clusterPolicy *ClusterPolicy) error {
-	return a.client.Post(a.context, "/policies/clusters/edit", clusterPolicy, nil)
+func formatRuleNumber(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
 }
 
-// Get returns cluster policy
-func (a ClusterPoliciesAPI) Get(policyID string) (policy ClusterPolicy, err error) {
-	err = a.client.Get(a.context, "/policies/clusters/get", policyIDWrapper{policyID}, &policy)
-	return
+func formatRuleValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if f, ok := v.(float64); ok {
+		return formatRuleNumber(&f)
+	}
+	return fmt.Sprint(v)
 }
 
-// Delete removes cluster policy
-func (a ClusterPoliciesAPI) Delete(policyID string) error {
-	return a.client.Post(a.context, "/policies/clusters/delete", policyIDWrapper{policyID}, nil)
+// definitionToRules converts policy definition to rule blocks, where rules keep the order
+// of previous state, so that only changed rules show up in the diff
+func definitionToRules(data string, order []string) ([]interface{}, error) {
+	definition, err := clusters.ParseClusterPolicyDefinition(data)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{}
+	known := map[string]bool{}
+	for _, path := range order {
+		if _, ok := definition[path]; ok && !known[path] {
+			paths = append(paths, path)
+			known[path] = true
+		}
+	}
+	remaining := []string{}
+	for path := range definition {
+		if !known[path] {
+			remaining = append(remaining, path)
+		}
+	}
+	sort.Strings(remaining)
+	rules := []interface{}{}
+	for _, path := range append(paths, remaining...) {
+		rule := definition[path]
+		values := []interface{}{}
+		for _, v := range rule.Values {
+			values = append(values, formatRuleValue(v))
+		}
+		rules = append(rules, map[string]interface{}{
+			"path":          path,
+			"type":          rule.Type,
+			"value":         formatRuleValue(rule.Value),
+			"values":        values,
+			"pattern":       rule.Pattern,
+			"min_value":     formatRuleNumber(rule.MinValue),
+			"max_value":     formatRuleNumber(rule.MaxValue),
+			"default_value": formatRuleValue(rule.DefaultValue),
+			"is_optional":   rule.IsOptional,
+			"hidden":        rule.Hidden,
+		})
+	}
+	return rules, nil
 }
 
-func parsePolicyFromData(d *schema.ResourceData) (*ClusterPolicy, error) {
-	clusterPolicy := new(ClusterPolicy)
-	clusterPolicy.PolicyID = d.Id()
-	if name, ok := d.GetOk("name"); ok {
-		clusterPolicy.Name = name.(string)
+func validateRuleNumber(i interface{}, k string) (_ []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
 	}
-	if data, ok := d.GetOk("definition"); ok {
-		clusterPolicy.Definition = data.(string)
+	if _, err := strconv.ParseFloat(v, 64); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a number, got %s", k, v)}
 	}
-	return clusterPolicy, nil
+	return
 }
 
 // ResourceClusterPolicy ...
@@ -102,8 +200,90 @@ func ResourceClusterPolicy() *schema.Resource {
 				Description: "Policy definition JSON document expressed in\n" +
 					"Databricks Policy Definition Language.",
 				ValidateFunc: validation.StringIsJSON,
+				ExactlyOneOf: []string{"definition", "rule"},
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Policy definition expressed as rules for individual\n" +
+					"cluster attributes.",
+				ExactlyOneOf: []string{"definition", "rule"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								clusters.PolicyRuleFixed,
+								clusters.PolicyRuleForbidden,
+								clusters.PolicyRuleAllowlist,
+								clusters.PolicyRuleBlocklist,
+								clusters.PolicyRuleRegex,
+								clusters.PolicyRuleRange,
+								clusters.PolicyRuleUnlimited,
+							}, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"values": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"pattern": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// numbers are kept as strings, so that zero limit differs from no limit
+						"min_value": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateRuleNumber,
+						},
+						"max_value": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateRuleNumber,
+						},
+						"default_value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"is_optional": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"hidden": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
 			},
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			rules, ok := d.GetOk("rule")
+			if !ok || !d.NewValueKnown("rule") {
+				return nil
+			}
+			for i := range rules.([]interface{}) {
+				for _, field := range []string{"path", "value", "values", "pattern",
+					"min_value", "max_value", "default_value"} {
+					if !d.NewValueKnown(fmt.Sprintf("rule.%d.%s", i, field)) {
+						// values may come from other resources
+						return nil
+					}
+				}
+			}
+			_, err := rulesToDefinition(rules.([]interface{}))
+			return err
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			clusterPolicy, err := parsePolicyFromData(d)
 			if err != nil {
@@ -123,7 +303,26 @@ func ResourceClusterPolicy() *schema.Resource {
 			if err = d.Set("name", clusterPolicy.Name); err != nil {
 				return err
 			}
-			if err = d.Set("definition", clusterPolicy.Definition); err != nil {
+			if rules, ok := d.GetOk("rule"); ok {
+				order := []string{}
+				for _, r := range rules.([]interface{}) {
+					order = append(order, r.(map[string]interface{})["path"].(string))
+				}
+				rules, err := definitionToRules(clusterPolicy.Definition, order)
+				if err != nil {
+					// policy was changed outside of Terraform, so that it cannot be
+					// represented as rules. It's kept as definition and the next
+					// apply replaces it with the configured rules.
+					log.Printf("[WARN] Policy %s cannot be represented as rules: %s", d.Id(), err)
+					rules = []interface{}{}
+					if err = d.Set("definition", clusterPolicy.Definition); err != nil {
+						return err
+					}
+				}
+				if err = d.Set("rule", rules); err != nil {
+					return err
+				}
+			} else if err = d.Set("definition", clusterPolicy.Definition); err != nil {
 				return err
 			}
 			if err = d.Set("policy_id", clusterPolicy.PolicyID); err != nil {
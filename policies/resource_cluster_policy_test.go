@@ -236,3 +236,243 @@ func TestResourceClusterPolicyDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceClusterPolicyCreate_Rules(t *testing.T) {
+	definition := `{"autoscale.max_workers":{"type":"range","minValue":1,"maxValue":0},` +
+		`"autotermination_minutes":{"type":"fixed","value":20,"hidden":true},` +
+		`"dbus_per_hour":{"type":"range","maxValue":10.5},` +
+		`"node_type_id":{"type":"allowlist","values":["i3.xlarge","i3.2xlarge"],"defaultValue":"i3.xlarge"},` +
+		`"spark_conf.spark.databricks.io.cache.enabled":{"type":"fixed","value":"true"}}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: ClusterPolicy{
+					Name:       "Dummy",
+					Definition: definition,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Dummy",
+					Definition: definition,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "spark_conf.spark.databricks.io.cache.enabled"
+			type = "fixed"
+			value = "true"
+		}
+		rule {
+			path = "autotermination_minutes"
+			type = "fixed"
+			value = 20
+			hidden = true
+		}
+		rule {
+			path = "node_type_id"
+			type = "allowlist"
+			values = ["i3.xlarge", "i3.2xlarge"]
+			default_value = "i3.xlarge"
+		}
+		rule {
+			path = "autoscale.max_workers"
+			type = "range"
+			min_value = 1
+			max_value = 0
+		}
+		rule {
+			path = "dbus_per_hour"
+			type = "range"
+			max_value = 10.5
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "", d.Get("definition"))
+	// order of rules from configuration is kept
+	assert.Equal(t, "spark_conf.spark.databricks.io.cache.enabled", d.Get("rule.0.path"))
+	assert.Equal(t, "20", d.Get("rule.1.value"))
+	assert.Equal(t, "i3.2xlarge", d.Get("rule.2.values.1"))
+	assert.Equal(t, "0", d.Get("rule.3.max_value"))
+	assert.Equal(t, "10.5", d.Get("rule.4.max_value"))
+}
+
+func TestResourceClusterPolicyCreate_VirtualAttributeRules(t *testing.T) {
+	definition := `{"cluster_log_conf.path":{"type":"fixed","value":"dbfs:/cluster-logs"},` +
+		`"cluster_log_conf.type":{"type":"fixed","value":"DBFS"}}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/policies/clusters/create",
+				ExpectedRequest: ClusterPolicy{
+					Name:       "Logs",
+					Definition: definition,
+				},
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Logs",
+					Definition: definition,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		HCL: `
+		name = "Logs"
+		rule {
+			path = "cluster_log_conf.type"
+			type = "fixed"
+			value = "DBFS"
+		}
+		rule {
+			path = "cluster_log_conf.path"
+			type = "fixed"
+			value = "dbfs:/cluster-logs"
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "cluster_log_conf.type", d.Get("rule.0.path"))
+	assert.Equal(t, "dbfs:/cluster-logs", d.Get("rule.1.value"))
+}
+
+func TestResourceClusterPolicyRead_RulesOrder(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID: "abc",
+					Name:     "Dummy",
+					Definition: `{"spark_version": {"type": "unlimited"},
+					"instance_pool_id": {"type": "forbidden"},
+					"autotermination_minutes": {"type": "fixed", "value": 20}}`,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "spark_version"
+			type = "unlimited"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("rule.#"))
+	assert.Equal(t, "spark_version", d.Get("rule.0.path"))
+	assert.Equal(t, "autotermination_minutes", d.Get("rule.1.path"))
+	assert.Equal(t, "instance_pool_id", d.Get("rule.2.path"))
+}
+
+func TestResourceClusterPolicyRead_UnknownRuleType(t *testing.T) {
+	definition := `{"spark_version": {"type": "unlimited"},
+					"autotermination_minutes": {"type": "somethingNew", "value": 20}}`
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=abc",
+				Response: ClusterPolicy{
+					PolicyID:   "abc",
+					Name:       "Dummy",
+					Definition: definition,
+				},
+			},
+		},
+		Resource: ResourceClusterPolicy(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "spark_version"
+			type = "unlimited"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("rule.#"))
+	assert.Equal(t, definition, d.Get("definition"))
+}
+
+func TestResourceClusterPolicyCreate_NoDefinition(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		Create:   true,
+		HCL:      `name = "Dummy"`,
+	}.ExpectError(t, "invalid config supplied. [definition] Invalid combination of arguments. "+
+		"[rule] Invalid combination of arguments")
+}
+
+func TestResourceClusterPolicyCreate_InvalidRules(t *testing.T) {
+	for rule, expected := range map[string]string{
+		`path = "autotermination_minutes"
+		type = "tpye"`: "invalid config supplied. [rule.#.type] expected rule.0.type to be one of",
+		`path = "autotermination_mins"
+		type = "unlimited"`: "rule for autotermination_mins: unknown cluster attribute: autotermination_mins",
+		`path = "autotermination_minutes"
+		type = "fixed"
+		value = "soon"`: "rule for autotermination_minutes: soon is not an integer",
+		`path = "node_type_id"
+		type = "allowlist"`: "rule for node_type_id: values are required for allowlist rule",
+		`path = "num_workers"
+		type = "range"
+		max_value = "many"`: "invalid config supplied. [rule.#.max_value] expected rule.0.max_value to be a number, got many",
+		`path = "custom_tags.*"
+		type = "regex"
+		pattern = "("`: "rule for custom_tags.*: invalid pattern: error parsing regexp",
+	} {
+		_, err := qa.ResourceFixture{
+			Resource: ResourceClusterPolicy(),
+			Create:   true,
+			HCL: `
+			name = "Dummy"
+			rule {
+				` + rule + `
+			}`,
+		}.Apply(t)
+		qa.AssertErrorStartsWith(t, err, expected)
+	}
+}
+
+func TestResourceClusterPolicyCreate_DuplicateRules(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceClusterPolicy(),
+		Create:   true,
+		HCL: `
+		name = "Dummy"
+		rule {
+			path = "spark_version"
+			type = "unlimited"
+		}
+		rule {
+			path = "spark_version"
+			type = "forbidden"
+		}`,
+	}.ExpectError(t, "duplicate rule for spark_version")
+}
@@ -0,0 +1,79 @@
+package pools
+
+import (
+	"context"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// computedPoolSchema makes schema of pool information read-only
+func computedPoolSchema(s *schema.Schema) {
+	pool := s.Elem.(*schema.Resource).Schema
+	common.ComputedSchema(pool)
+	if p, err := common.SchemaPath(pool, "preloaded_docker_image", "basic_auth", "password"); err == nil {
+		p.Sensitive = true
+	}
+}
+
+// DataSourceInstancePool returns information about instance pool specified by ID or unique name
+func DataSourceInstancePool() *schema.Resource {
+	type poolData struct {
+		InstancePoolID   string                `json:"instance_pool_id,omitempty" tf:"computed"`
+		InstancePoolName string                `json:"instance_pool_name,omitempty" tf:"computed"`
+		PoolInfo         *InstancePoolAndStats `json:"pool_info,omitempty" tf:"computed"`
+	}
+	s := common.StructToSchema(poolData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["instance_pool_id"].ExactlyOneOf = []string{"instance_pool_id", "instance_pool_name"}
+		s["instance_pool_name"].ExactlyOneOf = []string{"instance_pool_id", "instance_pool_name"}
+		computedPoolSchema(s["pool_info"])
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this poolData
+			err := common.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			poolsAPI := NewInstancePoolsAPI(ctx, m)
+			if this.InstancePoolID != "" {
+				pool, err := poolsAPI.Get(this.InstancePoolID)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				this.PoolInfo = &pool
+			} else {
+				list, err := poolsAPI.List()
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				for _, pool := range list.InstancePools {
+					if pool.InstancePoolName != this.InstancePoolName {
+						continue
+					}
+					if this.PoolInfo != nil {
+						return diag.Errorf("there is more than one instance pool with name '%s'",
+							this.InstancePoolName)
+					}
+					found := pool
+					this.PoolInfo = &found
+				}
+				if this.PoolInfo == nil {
+					return diag.Errorf("there is no instance pool with name '%s'", this.InstancePoolName)
+				}
+			}
+			this.InstancePoolID = this.PoolInfo.InstancePoolID
+			this.InstancePoolName = this.PoolInfo.InstancePoolName
+			d.SetId(this.InstancePoolID)
+			err = common.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
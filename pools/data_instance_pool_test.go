@@ -0,0 +1,115 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/clusters"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+var poolsListFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/instance-pools/list",
+	ReuseRequest: true,
+	Response: InstancePoolList{
+		InstancePools: []InstancePoolAndStats{
+			{
+				InstancePoolID:   "b",
+				InstancePoolName: "Shared i3",
+				NodeTypeID:       "i3.xlarge",
+				CustomTags:       map[string]string{"Team": "data"},
+				PreloadedSparkVersions: []string{
+					"7.3.x-scala2.12",
+				},
+				PreloadedDockerImages: []clusters.DockerImage{
+					{
+						URL: "databricksruntime/standard:latest",
+					},
+				},
+				Stats: &InstancePoolStats{
+					UsedCount:        3,
+					IdleCount:        1,
+					PendingUsedCount: 2,
+				},
+			},
+			{
+				InstancePoolID:   "a",
+				InstancePoolName: "Shared m5",
+				NodeTypeID:       "m5.xlarge",
+				CustomTags:       map[string]string{"Team": "data"},
+			},
+			{
+				InstancePoolID:   "c",
+				InstancePoolName: "Shared i3",
+				NodeTypeID:       "i3.xlarge",
+			},
+		},
+	},
+}
+
+func TestInstancePoolDataSource_ByID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:   "abc",
+					InstancePoolName: "Pool",
+					NodeTypeID:       "i3.xlarge",
+					MaxCapacity:      10,
+					State:            "ACTIVE",
+					Stats: &InstancePoolStats{
+						UsedCount:        2,
+						IdleCount:        3,
+						PendingIdleCount: 1,
+					},
+				},
+			},
+		},
+		Resource:    DataSourceInstancePool(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `instance_pool_id = "abc"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Pool", d.Get("instance_pool_name"))
+	assert.Equal(t, "i3.xlarge", d.Get("pool_info.0.node_type_id"))
+	assert.Equal(t, "ACTIVE", d.Get("pool_info.0.state"))
+	assert.Equal(t, 2, d.Get("pool_info.0.stats.0.used_count"))
+	assert.Equal(t, 3, d.Get("pool_info.0.stats.0.idle_count"))
+	assert.Equal(t, 1, d.Get("pool_info.0.stats.0.pending_idle_count"))
+}
+
+func TestInstancePoolDataSource_ByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{poolsListFixture},
+		Resource:    DataSourceInstancePool(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `instance_pool_name = "Shared m5"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "a", d.Id())
+	assert.Equal(t, "m5.xlarge", d.Get("pool_info.0.node_type_id"))
+}
+
+func TestInstancePoolDataSource_NameErrors(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Shared i3": "there is more than one instance pool with name 'Shared i3'",
+		"Unknown":   "there is no instance pool with name 'Unknown'",
+	} {
+		qa.ResourceFixture{
+			Fixtures:    []qa.HTTPFixture{poolsListFixture},
+			Resource:    DataSourceInstancePool(),
+			Read:        true,
+			NonWritable: true,
+			ID:          "_",
+			HCL:         `instance_pool_name = "` + name + `"`,
+		}.ExpectError(t, expected)
+	}
+}
@@ -0,0 +1,78 @@
+package pools
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// InstancePoolsFilter is a wrapper for local filtering of instance pools
+type InstancePoolsFilter struct {
+	InstancePoolNameContains string                 `json:"instance_pool_name_contains,omitempty"`
+	CustomTags               map[string]string      `json:"custom_tags,omitempty"`
+	NodeTypeID               string                 `json:"node_type_id,omitempty"`
+	IDs                      []string               `json:"ids,omitempty" tf:"computed,slice_set"`
+	InstancePools            []InstancePoolAndStats `json:"instance_pools,omitempty" tf:"computed"`
+}
+
+// Matches tells if instance pool fits the filter
+func (f InstancePoolsFilter) Matches(pool InstancePoolAndStats) bool {
+	if f.InstancePoolNameContains != "" && !strings.Contains(
+		strings.ToLower(pool.InstancePoolName), strings.ToLower(f.InstancePoolNameContains)) {
+		return false
+	}
+	for k, v := range f.CustomTags {
+		if pool.CustomTags[k] != v {
+			return false
+		}
+	}
+	if f.NodeTypeID != "" && pool.NodeTypeID != f.NodeTypeID {
+		return false
+	}
+	return true
+}
+
+// DataSourceInstancePools returns instance pools, that match given criteria
+func DataSourceInstancePools() *schema.Resource {
+	s := common.StructToSchema(InstancePoolsFilter{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		computedPoolSchema(s["instance_pools"])
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var filter InstancePoolsFilter
+			err := common.DataToStructPointer(d, s, &filter)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			list, err := NewInstancePoolsAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			filter.IDs = []string{}
+			filter.InstancePools = []InstancePoolAndStats{}
+			for _, pool := range list.InstancePools {
+				if filter.Matches(pool) {
+					filter.IDs = append(filter.IDs, pool.InstancePoolID)
+					filter.InstancePools = append(filter.InstancePools, pool)
+				}
+			}
+			sort.Strings(filter.IDs)
+			sort.Slice(filter.InstancePools, func(i, j int) bool {
+				return filter.InstancePools[i].InstancePoolID < filter.InstancePools[j].InstancePoolID
+			})
+			d.SetId("_")
+			err = common.StructToData(filter, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package pools
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstancePoolsDataSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{poolsListFixture},
+		Resource:    DataSourceInstancePools(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL: `
+		instance_pool_name_contains = "shared"
+		custom_tags = {
+			Team = "data"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, []interface{}{"a", "b"}, d.Get("ids").(*schema.Set).List())
+	assert.Equal(t, 2, d.Get("instance_pools.#"))
+	assert.Equal(t, "b", d.Get("instance_pools.1.instance_pool_id"))
+	assert.Equal(t, 3, d.Get("instance_pools.1.stats.0.used_count"))
+	assert.Equal(t, 2, d.Get("instance_pools.1.stats.0.pending_used_count"))
+	assert.Equal(t, "7.3.x-scala2.12", d.Get("instance_pools.1.preloaded_spark_versions.0"))
+	assert.Equal(t, 1, d.Get("instance_pools.1.preloaded_docker_image.#"))
+}
+
+func TestInstancePoolsDataSource_NodeType(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{poolsListFixture},
+		Resource:    DataSourceInstancePools(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+		HCL:         `node_type_id = "i3.xlarge"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, []interface{}{"b", "c"}, d.Get("ids").(*schema.Set).List())
+}
+
+func TestInstancePoolsDataSource_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/list",
+				Status:   500,
+				Response: common.APIErrorBody{
+					ErrorCode: "INTERNAL_ERROR",
+					Message:   "Nope",
+				},
+			},
+		},
+		Resource:    DataSourceInstancePools(),
+		Read:        true,
+		NonWritable: true,
+		ID:          "_",
+	}.ExpectError(t, "Nope")
+}
@@ -37,7 +37,8 @@ type InstancePoolDiskSpec struct {
 	DiskSize  int32                 `json:"disk_size,omitempty"`
 }
 
-// InstancePool describes the instance pool object on Databricks
+// InstancePool describes the instance pool object on Databricks. Name, sizing, idle instance
+// termination and custom tags are changed in place, other fields are immutable.
 type InstancePool struct {
 	InstancePoolID                     string                       `json:"instance_pool_id,omitempty" tf:"computed"`
 	InstancePoolName                   string                       `json:"instance_pool_name"`
@@ -47,7 +48,7 @@ type InstancePool struct {
 	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty" tf:"force_new,suppress_diff"`
 	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty" tf:"force_new,suppress_diff"`
 	NodeTypeID                         string                       `json:"node_type_id" tf:"force_new"`
-	CustomTags                         map[string]string            `json:"custom_tags,omitempty" tf:"force_new"`
+	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
 	EnableElasticDisk                  bool                         `json:"enable_elastic_disk,omitempty" tf:"force_new"`
 	DiskSpec                           *InstancePoolDiskSpec        `json:"disk_spec,omitempty" tf:"force_new"`
 	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty" tf:"force_new"`
@@ -118,6 +119,14 @@ func (a InstancePoolsAPI) Read(instancePoolID string) (ip InstancePool, err erro
 	return
 }
 
+// Get retrieves the information for a instance pool together with its usage statistics
+func (a InstancePoolsAPI) Get(instancePoolID string) (ip InstancePoolAndStats, err error) {
+	err = a.client.Get(a.context, "/instance-pools/get", map[string]string{
+		"instance_pool_id": instancePoolID,
+	}, &ip)
+	return
+}
+
 // List retrieves the list of existing instance pools
 func (a InstancePoolsAPI) List() (ipl InstancePoolList, err error) {
 	err = a.client.Get(a.context, "/instance-pools/list", nil, &ipl)
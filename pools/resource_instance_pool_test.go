@@ -331,3 +331,81 @@ func TestResourceInstancePoolDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceInstancePoolUpdate_CustomTagsInPlace(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: InstancePool{
+					EnableElasticDisk:                  true,
+					InstancePoolID:                     "abc",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 20,
+					InstancePoolName:                   "Shared Pool",
+					CustomTags: map[string]string{
+						"Team": "data-engineering",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					EnableElasticDisk:                  true,
+					InstancePoolID:                     "abc",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 20,
+					InstancePoolName:                   "Shared Pool",
+					CustomTags: map[string]string{
+						"Team": "data-engineering",
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"idle_instance_autotermination_minutes": "20",
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "i3.xlarge",
+			"enable_elastic_disk":                   "true",
+			"custom_tags.%":                         "1",
+			"custom_tags.Team":                      "data",
+		},
+		HCL: `
+		idle_instance_autotermination_minutes = 20
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		custom_tags = {
+			"Team" = "data-engineering"
+		}`,
+		Update: true,
+		ID:     "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	// pool is edited, not recreated
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "data-engineering", d.Get("custom_tags.Team"))
+}
+
+func TestResourceInstancePoolUpdate_PreloadedSparkVersionsRequireNew(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"idle_instance_autotermination_minutes": "20",
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "i3.xlarge",
+			"enable_elastic_disk":                   "true",
+			"preloaded_spark_versions.#":            "1",
+			"preloaded_spark_versions.0":            "7.3.x-scala2.12",
+		},
+		HCL: `
+		idle_instance_autotermination_minutes = 20
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		preloaded_spark_versions = ["8.3.x-scala2.12"]`,
+		Update: true,
+		ID:     "abc",
+	}.ExpectError(t, "changes require new: preloaded_spark_versions.0")
+}
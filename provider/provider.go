@@ -33,10 +33,19 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_aws_crossaccount_policy": access.DataAwsCrossAccountPolicy(),
 			"databricks_aws_assume_role_policy":  access.DataAwsAssumeRolePolicy(),
 			"databricks_aws_bucket_policy":       access.DataAwsBucketPolicy(),
+			"databricks_cluster":                 clusters.DataSourceCluster(),
+			"databricks_cluster_cost_estimate":   clusters.DataSourceClusterCostEstimate(),
+			"databricks_cluster_events":          clusters.DataSourceClusterEvents(),
+			"databricks_clusters":                clusters.DataSourceClusters(),
+			"databricks_cron_preview":            jobs.DataSourceCronPreview(),
 			"databricks_current_user":            identity.DataSourceCurrentUser(),
 			"databricks_dbfs_file":               storage.DataSourceDBFSFile(),
 			"databricks_dbfs_file_paths":         storage.DataSourceDBFSFilePaths(),
 			"databricks_group":                   identity.DataSourceGroup(),
+			"databricks_instance_pool":           pools.DataSourceInstancePool(),
+			"databricks_instance_pools":          pools.DataSourceInstancePools(),
+			"databricks_job":                     jobs.DataSourceJob(),
+			"databricks_jobs":                    jobs.DataSourceJobs(),
 			"databricks_node_type":               clusters.DataSourceNodeType(),
 			"databricks_notebook":                workspace.DataSourceNotebook(),
 			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
@@ -61,6 +70,8 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_instance_profile":            identity.ResourceInstanceProfile(),
 			"databricks_ip_access_list":              access.ResourceIPAccessList(),
 			"databricks_job":                         jobs.ResourceJob(),
+			"databricks_job_run":                     jobs.ResourceJobRun(),
+			"databricks_library":                     clusters.ResourceLibrary(),
 			"databricks_mount":                       storage.ResourceDatabricksMount(),
 			"databricks_mws_customer_managed_keys":   mws.ResourceCustomerManagedKey(),
 			"databricks_mws_credentials":             mws.ResourceCredentials(),
@@ -71,6 +82,7 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_mws_vpc_endpoint":            mws.ResourceVPCEndpoint(),
 			"databricks_mws_workspaces":              mws.ResourceWorkspace(),
 			"databricks_notebook":                    workspace.ResourceNotebook(),
+			"databricks_notification_destination":    workspace.ResourceNotificationDestination(),
 			"databricks_obo_token":                   identity.ResourceOboToken(),
 			"databricks_permissions":                 access.ResourcePermissions(),
 			"databricks_pipeline":                    pipelines.ResourcePipeline(),
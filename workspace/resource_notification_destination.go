@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// SlackConfig contains the URL of Slack incoming webhook
+type SlackConfig struct {
+	URL string `json:"url"`
+}
+
+// PagerdutyConfig contains the integration key of PagerDuty service
+type PagerdutyConfig struct {
+	IntegrationKey string `json:"integration_key"`
+}
+
+// GenericWebhookConfig contains the URL and optional basic authentication of HTTP webhook
+type GenericWebhookConfig struct {
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// NotificationDestinationConfig contains settings of exactly one type of destination
+type NotificationDestinationConfig struct {
+	Slack          *SlackConfig          `json:"slack,omitempty"`
+	Pagerduty      *PagerdutyConfig      `json:"pagerduty,omitempty"`
+	GenericWebhook *GenericWebhookConfig `json:"generic_webhook,omitempty"`
+}
+
+// NotificationDestination is a webhook, that is notified about events, like completed job runs.
+// Secrets of the destination are never returned by the API.
+type NotificationDestination struct {
+	ID              string                         `json:"id,omitempty" tf:"computed"`
+	DisplayName     string                         `json:"display_name"`
+	DestinationType string                         `json:"destination_type,omitempty" tf:"computed"`
+	Config          *NotificationDestinationConfig `json:"config"`
+}
+
+// request carries only the writable fields, because destination type is derived from config
+func (nd NotificationDestination) request() notificationDestinationRequest {
+	return notificationDestinationRequest{
+		DisplayName: nd.DisplayName,
+		Config:      nd.Config,
+	}
+}
+
+type notificationDestinationRequest struct {
+	DisplayName string                         `json:"display_name"`
+	Config      *NotificationDestinationConfig `json:"config"`
+}
+
+// NewNotificationDestinationsAPI creates NotificationDestinationsAPI instance from provider meta
+func NewNotificationDestinationsAPI(ctx context.Context, m interface{}) NotificationDestinationsAPI {
+	return NotificationDestinationsAPI{
+		client:  m.(*common.DatabricksClient),
+		context: ctx,
+	}
+}
+
+// NotificationDestinationsAPI exposes the Notification Destinations API
+type NotificationDestinationsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create creates notification destination and returns it with the new ID
+func (a NotificationDestinationsAPI) Create(nd NotificationDestination) (created NotificationDestination, err error) {
+	err = a.client.Post(a.context, "/notification-destinations", nd.request(), &created)
+	return
+}
+
+// Get returns notification destination without its secrets
+func (a NotificationDestinationsAPI) Get(id string) (nd NotificationDestination, err error) {
+	err = a.client.Get(a.context, fmt.Sprintf("/notification-destinations/%s", id), nil, &nd)
+	return
+}
+
+// Update changes display name and config of notification destination
+func (a NotificationDestinationsAPI) Update(id string, nd NotificationDestination) error {
+	return a.client.Patch(a.context, fmt.Sprintf("/notification-destinations/%s", id), nd.request())
+}
+
+// Delete removes notification destination
+func (a NotificationDestinationsAPI) Delete(id string) error {
+	return a.client.Delete(a.context, fmt.Sprintf("/notification-destinations/%s", id), nil)
+}
+
+// ResourceNotificationDestination manages Slack, PagerDuty and generic webhook destinations
+func ResourceNotificationDestination() *schema.Resource {
+	s := common.StructToSchema(NotificationDestination{},
+		func(s map[string]*schema.Schema) map[string]*schema.Schema {
+			delete(s, "id")
+			config := s["config"].Elem.(*schema.Resource).Schema
+			types := []string{"config.0.slack", "config.0.pagerduty", "config.0.generic_webhook"}
+			for _, t := range []string{"slack", "pagerduty", "generic_webhook"} {
+				config[t].ExactlyOneOf = types
+			}
+			for _, path := range [][]string{
+				{"config", "slack", "url"},
+				{"config", "pagerduty", "integration_key"},
+				{"config", "generic_webhook", "url"},
+				{"config", "generic_webhook", "username"},
+				{"config", "generic_webhook", "password"},
+			} {
+				if p, err := common.SchemaPath(s, path...); err == nil {
+					p.Sensitive = true
+				}
+			}
+			if p, err := common.SchemaPath(s, "config", "slack", "url"); err == nil {
+				p.ValidateFunc = validation.IsURLWithHTTPS
+			}
+			if p, err := common.SchemaPath(s, "config", "generic_webhook", "url"); err == nil {
+				p.ValidateFunc = validation.IsURLWithScheme([]string{"https", "http"})
+			}
+			return s
+		})
+	return common.Resource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var nd NotificationDestination
+			if err := common.DataToStructPointer(d, s, &nd); err != nil {
+				return err
+			}
+			created, err := NewNotificationDestinationsAPI(ctx, c).Create(nd)
+			if err != nil {
+				return err
+			}
+			d.SetId(created.ID)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			nd, err := NewNotificationDestinationsAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			// config is kept from the state, as the API doesn't return URLs, keys and passwords
+			if err = d.Set("display_name", nd.DisplayName); err != nil {
+				return err
+			}
+			return d.Set("destination_type", nd.DestinationType)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var nd NotificationDestination
+			if err := common.DataToStructPointer(d, s, &nd); err != nil {
+				return err
+			}
+			return NewNotificationDestinationsAPI(ctx, c).Update(d.Id(), nd)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewNotificationDestinationsAPI(ctx, c).Delete(d.Id())
+		},
+	}.ToResource()
+}
@@ -0,0 +1,206 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/terraform-provider-databricks/common"
+	"github.com/databrickslabs/terraform-provider-databricks/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceNotificationDestinationCreate_Slack(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/notification-destinations",
+				ExpectedRequest: NotificationDestination{
+					DisplayName: "Alerts",
+					Config: &NotificationDestinationConfig{
+						Slack: &SlackConfig{
+							URL: "https://hooks.slack.com/services/T0/B0/XXX",
+						},
+					},
+				},
+				Response: NotificationDestination{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: map[string]interface{}{
+					"id":               "abc",
+					"display_name":     "Alerts",
+					"destination_type": "SLACK",
+					"config": map[string]interface{}{
+						"slack": map[string]interface{}{
+							"url_set": true,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Create:   true,
+		HCL: `
+		display_name = "Alerts"
+		config {
+			slack {
+				url = "https://hooks.slack.com/services/T0/B0/XXX"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "SLACK", d.Get("destination_type"))
+	assert.Equal(t, "https://hooks.slack.com/services/T0/B0/XXX", d.Get("config.0.slack.0.url"),
+		"secret must be kept in the state")
+}
+
+func TestResourceNotificationDestinationCreate_ConflictingTypes(t *testing.T) {
+	qa.ResourceFixture{
+		Resource: ResourceNotificationDestination(),
+		Create:   true,
+		HCL: `
+		display_name = "Alerts"
+		config {
+			slack {
+				url = "https://hooks.slack.com/services/T0/B0/XXX"
+			}
+			pagerduty {
+				integration_key = "xyz"
+			}
+		}`,
+	}.ExpectError(t, "invalid config supplied. "+
+		"[config.#.generic_webhook] Invalid combination of arguments. "+
+		"[config.#.pagerduty] Invalid combination of arguments. "+
+		"[config.#.slack] Invalid combination of arguments")
+}
+
+func TestResourceNotificationDestinationCreate_Error(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/notification-destinations",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Invalid integration key",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Create:   true,
+		HCL: `
+		display_name = "On-call"
+		config {
+			pagerduty {
+				integration_key = "xyz"
+			}
+		}`,
+	}.ExpectError(t, "Invalid integration key")
+}
+
+func TestResourceNotificationDestinationRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Notification destination abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
+func TestResourceNotificationDestinationUpdate_Webhook(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/notification-destinations/abc",
+				ExpectedRequest: NotificationDestination{
+					DisplayName: "Incidents",
+					Config: &NotificationDestinationConfig{
+						GenericWebhook: &GenericWebhookConfig{
+							URL:      "https://incidents.example.com/hook",
+							Username: "databricks",
+							Password: "s3cr3t",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations/abc",
+				Response: NotificationDestination{
+					ID:              "abc",
+					DisplayName:     "Incidents",
+					DestinationType: "WEBHOOK",
+				},
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"display_name":                   "Incidents",
+			"destination_type":               "WEBHOOK",
+			"config.#":                       "1",
+			"config.0.generic_webhook.#":     "1",
+			"config.0.generic_webhook.0.url": "https://incidents.example.com/hook",
+		},
+		HCL: `
+		display_name = "Incidents"
+		config {
+			generic_webhook {
+				url = "https://incidents.example.com/hook"
+				username = "databricks"
+				password = "s3cr3t"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "s3cr3t", d.Get("config.0.generic_webhook.0.password"))
+}
+
+func TestResourceNotificationDestinationDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/notification-destinations/abc",
+			},
+		},
+		Resource: ResourceNotificationDestination(),
+		Delete:   true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestResourceNotificationDestination_SecretsAreSensitive(t *testing.T) {
+	s := ResourceNotificationDestination().Schema
+	for _, path := range [][]string{
+		{"config", "slack", "url"},
+		{"config", "pagerduty", "integration_key"},
+		{"config", "generic_webhook", "url"},
+		{"config", "generic_webhook", "username"},
+		{"config", "generic_webhook", "password"},
+	} {
+		p, err := common.SchemaPath(s, path...)
+		assert.NoError(t, err)
+		assert.True(t, p.Sensitive, "%v must be sensitive", path)
+	}
+}